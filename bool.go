@@ -1,25 +1,100 @@
 package multiparse
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
+// BooleanLocales are vocabulary packs of the words used to write boolean
+// values in various languages, keyed by language code.  The "symbols"
+// pack holds language independent marks such as "✓" and "✗".  All words
+// are lowercase.
+var BooleanLocales = map[string]map[string]bool{
+	"en": {
+		"true": true, "t": true, "yes": true, "y": true, "on": true,
+		"false": false, "f": false, "no": false, "n": false, "off": false,
+	},
+	"de": {
+		"wahr": true, "ja": true, "j": true, "an": true, "ein": true,
+		"falsch": false, "nein": false, "aus": false,
+	},
+	"fr": {
+		"vrai": true, "oui": true, "o": true,
+		"faux": false, "non": false,
+	},
+	"es": {
+		"verdadero": true, "sí": true, "si": true, "s": true,
+		"falso": false, "no": false,
+	},
+	"it": {
+		"vero": true, "sì": true, "si": true,
+		"falso": false, "no": false,
+	},
+	"pt": {
+		"verdadeiro": true, "sim": true,
+		"falso": false, "não": false, "nao": false,
+	},
+	"nl": {
+		"waar": true, "ja": true,
+		"onwaar": false, "nee": false,
+	},
+	"symbols": {
+		"✓": true, "✔": true, "☑": true,
+		"✗": false, "✘": false, "☐": false,
+	},
+}
+
+// booleanDigits are the numeric representations of boolean values.
+var booleanDigits = map[string]bool{
+	"1": true,
+	"0": false,
+}
+
+// A BooleanParser determines whether a string represents a boolean value
+// by looking it up in its vocabulary.
 type BooleanParser struct {
+	// FoldCase makes the parser ignore case, so that "TRUE" and "Yes"
+	// are recognized.
+	FoldCase bool
+	// TrimSpace makes the parser ignore leading and trailing whitespace.
+	TrimSpace bool
+	// ExcludeDigits makes the parser reject "0" and "1", so that numeric
+	// columns are not mistaken for booleans.
+	ExcludeDigits bool
+	// Unexported fields.
 	m map[string]bool
 }
 
+// NewBooleanParser recognizes "0", "1", the English vocabulary
+// ("true", "t", "yes", "y", "on" and their negations) and the symbols
+// "✓" and "✗", ignoring case and surrounding whitespace.
 func NewBooleanParser() *BooleanParser {
-	m := map[string]bool{
-		"1":     true,
-		"yes":   true,
-		"true":  true,
-		"0":     false,
-		"no":    false,
-		"false": false,
+	return NewLocaleBooleanParser("en", "symbols")
+}
+
+// NewLocaleBooleanParser recognizes "0", "1" and the vocabularies of the
+// given BooleanLocales, ignoring case and surrounding whitespace.
+// Unknown locales are ignored.
+func NewLocaleBooleanParser(locales ...string) *BooleanParser {
+	m := make(map[string]bool)
+	for k, v := range booleanDigits {
+		m[k] = v
 	}
-	return NewCustomBooleanParser(m)
+	for _, locale := range locales {
+		for k, v := range BooleanLocales[locale] {
+			m[k] = v
+		}
+	}
+	p := NewCustomBooleanParser(m)
+	p.FoldCase = true
+	p.TrimSpace = true
+	return p
 }
 
+// NewCustomBooleanParser recognizes exactly the strings in the given
+// vocabulary.  Case folding and whitespace trimming are disabled.
 func NewCustomBooleanParser(m map[string]bool) *BooleanParser {
-	return &BooleanParser{m}
+	return &BooleanParser{m: m}
 }
 
 func (p BooleanParser) Parse(s string) (interface{}, error) {
@@ -31,7 +106,19 @@ func (p BooleanParser) ParseBool(s string) (bool, error) {
 }
 
 func (p BooleanParser) parse(s string) (bool, error) {
+	if p.TrimSpace {
+		s = strings.TrimSpace(s)
+	}
+	if p.ExcludeDigits {
+		if _, prs := booleanDigits[s]; prs {
+			return false, errors.New(ParseBoolError)
+		}
+	}
+
 	b, prs := p.m[s]
+	if !prs && p.FoldCase {
+		b, prs = p.m[strings.ToLower(s)]
+	}
 	if !prs {
 		return false, errors.New(ParseBoolError)
 	}
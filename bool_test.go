@@ -39,3 +39,83 @@ func TestParseBool(t *testing.T) {
 	}
 
 }
+
+func TestBooleanParserVocabulary(t *testing.T) {
+	tests := []struct {
+		in  string
+		out bool
+	}{
+		{"TRUE", true},
+		{"Yes", true},
+		{"Y", true},
+		{" on ", true},
+		{"off", false},
+		{"t", true},
+		{"F", false},
+		{"✓", true},
+		{"✗", false},
+	}
+
+	p := NewBooleanParser()
+	for _, tt := range tests {
+		b, err := p.ParseBool(tt.in)
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.out, b, tt.in)
+	}
+
+	_, err := p.ParseBool("ja")
+	assert.Error(t, err)
+}
+
+func TestLocaleBooleanParser(t *testing.T) {
+	tests := []struct {
+		in  string
+		out bool
+	}{
+		{"ja", true},
+		{"Nein", false},
+		{"OUI", true},
+		{"non", false},
+		{"sí", true},
+		{"SÍ", true},
+		{"1", true},
+	}
+
+	p := NewLocaleBooleanParser("de", "fr", "es", "unknown")
+	for _, tt := range tests {
+		b, err := p.ParseBool(tt.in)
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.out, b, tt.in)
+	}
+
+	_, err := p.ParseBool("yes")
+	assert.Error(t, err)
+}
+
+func TestBooleanParserExcludeDigits(t *testing.T) {
+	p := NewBooleanParser()
+	p.ExcludeDigits = true
+	for _, s := range []string{"0", "1", " 1 "} {
+		_, err := p.ParseBool(s)
+		assert.Error(t, err, s)
+	}
+	b, err := p.ParseBool("true")
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	parsed, err := NewCustomParser(NewNumericParser(), NewTimeParser(), p).ParseType("1")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsInt())
+	assert.False(t, parsed.IsBool())
+}
+
+func TestCustomBooleanParser(t *testing.T) {
+	p := NewCustomBooleanParser(map[string]bool{"Y": true, "N": false})
+	b, err := p.ParseBool("Y")
+	assert.NoError(t, err)
+	assert.True(t, b)
+	_, err = p.ParseBool("y")
+	assert.Error(t, err)
+	_, err = p.ParseBool(" N")
+	assert.Error(t, err)
+}
@@ -0,0 +1,135 @@
+package multiparse
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// byteSizeRegex matches the unit of a byte size, such as "", "B", "K",
+// "KB", "KiB" or "bytes", after it has been converted to upper case.
+var byteSizeRegex = regexp.MustCompile("^(?:([KMGTPE])(I)?B?|B|BYTES?)?$")
+
+// byteSizeExponents are the powers of the unit prefixes.
+var byteSizeExponents = map[string]int64{
+	"K": 1, "M": 2, "G": 3, "T": 4, "P": 5, "E": 6,
+}
+
+// A ByteSizeParser determines whether a string such as "1.5GiB", "200M",
+// "10 kB" or "4K" represents a number of bytes.  IEC units such as "KiB"
+// always denote powers of 1024.  Other units, such as "K" and "KB",
+// denote powers of 1000 unless the parser's Binary field is set.
+// Units are case insensitive and always denote bytes, never bits.
+type ByteSizeParser struct {
+	// Binary makes units such as "K" and "KB" denote powers of 1024.
+	Binary bool
+	// Unexported fields.
+	numeric *NumericParser
+}
+
+// NewByteSizeParser returns a ByteSizeParser with SI semantics that
+// parses magnitudes with the general NumericParser.
+func NewByteSizeParser() *ByteSizeParser {
+	return NewCustomByteSizeParser(NewNumericParser(), false)
+}
+
+// NewCustomByteSizeParser returns a ByteSizeParser that parses
+// magnitudes with the given NumericParser, using binary semantics for
+// units such as "K" and "KB" if binary is true.
+func NewCustomByteSizeParser(numeric *NumericParser, binary bool) *ByteSizeParser {
+	return &ByteSizeParser{
+		Binary:  binary,
+		numeric: numeric,
+	}
+}
+
+// Parse a string to determine if it represents a byte size.  The
+// returned value is a uint64.
+func (p ByteSizeParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParseByteSize is the same as Parse but returns a uint64.
+func (p ByteSizeParser) ParseByteSize(s string) (uint64, error) {
+	return p.parse(s)
+}
+
+// parse computes the byte count exactly from the decimal text of the
+// magnitude, rounding fractional bytes to the nearest byte, so that
+// "1.3 KiB" is 1331 bytes.  Negative sizes and sizes that overflow a
+// uint64 are errors.
+func (p ByteSizeParser) parse(s string) (uint64, error) {
+	parseErr := errors.New(ParseByteSizeError)
+
+	magnitude, unit := splitUnit(strings.TrimSpace(s))
+	m := byteSizeRegex.FindStringSubmatch(strings.ToUpper(unit))
+	if magnitude == "" || m == nil {
+		return 0, parseErr
+	}
+
+	n, text, err := p.numeric.parseText(magnitude)
+	if err != nil || n.IsMoney() || n.Float() < 0 {
+		return 0, parseErr
+	}
+	x, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return 0, parseErr
+	}
+
+	if m[1] != "" {
+		base := int64(1000)
+		if p.Binary || m[2] != "" {
+			base = 1024
+		}
+		scale := new(big.Int).Exp(big.NewInt(base), big.NewInt(byteSizeExponents[m[1]]), nil)
+		x.Mul(x, new(big.Rat).SetInt(scale))
+	}
+
+	// Round half up: (2*num + den) / (2*den).
+	num := new(big.Int).Lsh(x.Num(), 1)
+	num.Add(num, x.Denom())
+	den := new(big.Int).Lsh(x.Denom(), 1)
+	bytes := num.Quo(num, den)
+	if !bytes.IsUint64() {
+		return 0, errors.New(ParseByteSizeOverflowError)
+	}
+	return bytes.Uint64(), nil
+}
+
+// FormatByteSize renders a byte count with the largest unit in which it
+// is at least 1 after rounding to at most two decimal places, e.g.
+// "1.5 GiB" when binary is true and "1.61 GB" otherwise, and "1 MB"
+// rather than "1000 kB" for 999999 bytes.
+func FormatByteSize(n uint64, binary bool) string {
+	units := []string{"kB", "MB", "GB", "TB", "PB", "EB"}
+	base := 1000.0
+	if binary {
+		units = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+		base = 1024
+	}
+
+	if float64(n) < base {
+		return strconv.FormatUint(n, 10) + " B"
+	}
+	x := float64(n)
+	i := -1
+	for i+1 < len(units) && x >= base {
+		x /= base
+		i++
+	}
+	// Rounding may carry into the next unit, as 1048575 bytes would be
+	// "1024 KiB".
+	if i+1 < len(units) && roundTo(x, 2) >= base {
+		x /= base
+		i++
+	}
+	return strconv.FormatFloat(roundTo(x, 2), 'f', -1, 64) + " " + units[i]
+}
+
+// roundTo rounds x to the given number of decimal places.
+func roundTo(x float64, places int) float64 {
+	f, _ := strconv.ParseFloat(strconv.FormatFloat(x, 'f', places, 64), 64)
+	return f
+}
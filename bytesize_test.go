@@ -0,0 +1,89 @@
+package multiparse
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByteSizeParserParse(t *testing.T) {
+	tests := []struct {
+		in     string
+		si     uint64
+		binary uint64
+	}{
+		{"123", 123, 123},
+		{"123 B", 123, 123},
+		{"12 bytes", 12, 12},
+		{"1.5GiB", 1610612736, 1610612736},
+		{"200M", 200000000, 209715200},
+		{"10 kB", 10000, 10240},
+		{"4K", 4000, 4096},
+		{"4k", 4000, 4096},
+		{"1,024 MB", 1024000000, 1073741824},
+		{"0.1 GB", 100000000, 107374182},
+		{"1.3 KiB", 1331, 1331},
+		{"2 mib", 2097152, 2097152},
+		{"16EiB", 0, 0},
+		{"18446744073709551615", math.MaxUint64, math.MaxUint64},
+		{"18.446744073709551615 EB", math.MaxUint64, 0},
+	}
+
+	si := NewByteSizeParser()
+	binary := NewCustomByteSizeParser(NewNumericParser(), true)
+	for _, tt := range tests {
+		n, err := si.ParseByteSize(tt.in)
+		if tt.si == 0 {
+			assert.EqualError(t, err, ParseByteSizeOverflowError, tt.in)
+		} else {
+			assert.NoError(t, err, tt.in)
+			assert.Equal(t, tt.si, n, tt.in)
+		}
+
+		n, err = binary.ParseByteSize(tt.in)
+		if tt.binary == 0 {
+			assert.EqualError(t, err, ParseByteSizeOverflowError, tt.in)
+		} else {
+			assert.NoError(t, err, tt.in)
+			assert.Equal(t, tt.binary, n, tt.in)
+		}
+	}
+
+	fails := []string{"", "GB", "-1 KB", "$12", "12 KX", "12 Kib/s", "1.5.5 MB"}
+	for _, tt := range fails {
+		_, err := si.Parse(tt)
+		assert.EqualError(t, err, ParseByteSizeError, tt)
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	tests := []struct {
+		in     uint64
+		binary bool
+		out    string
+	}{
+		{0, false, "0 B"},
+		{999, false, "999 B"},
+		{1000, false, "1 kB"},
+		{1610612736, true, "1.5 GiB"},
+		{1610612736, false, "1.61 GB"},
+		{1023, true, "1023 B"},
+		{1 << 20, true, "1 MiB"},
+		{math.MaxUint64, true, "16 EiB"},
+		{math.MaxUint64, false, "18.45 EB"},
+		{1048575, true, "1 MiB"},
+		{999999, false, "1 MB"},
+		{999994999, false, "999.99 MB"},
+		{999999999, false, "1 GB"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.out, FormatByteSize(tt.in, tt.binary), tt.in)
+	}
+
+	p := NewCustomByteSizeParser(NewNumericParser(), true)
+	n, err := p.ParseByteSize(FormatByteSize(1<<30, true))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1<<30), n)
+}
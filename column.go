@@ -0,0 +1,338 @@
+package multiparse
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// A Kind is the most specific type a parsed string represents.
+type Kind int
+
+// Kinds of parsed values.  KindString is used for values that do not
+// parse as any other kind.
+const (
+	KindString Kind = iota
+	KindBool
+	KindInt
+	KindFloat
+	KindMoney
+	KindTime
+	KindQuantity
+	KindNetwork
+	KindIdentifier
+	KindCode
+	KindPhone
+	KindEmail
+	KindURL
+	KindCoordinate
+	KindRange
+	KindList
+)
+
+var kindNames = map[Kind]string{
+	KindString:     "string",
+	KindBool:       "bool",
+	KindInt:        "int",
+	KindFloat:      "float",
+	KindMoney:      "money",
+	KindTime:       "time",
+	KindQuantity:   "quantity",
+	KindNetwork:    "network",
+	KindIdentifier: "identifier",
+	KindCode:       "code",
+	KindPhone:      "phone",
+	KindEmail:      "email",
+	KindURL:        "url",
+	KindCoordinate: "coordinate",
+	KindRange:      "range",
+	KindList:       "list",
+}
+
+func (k Kind) String() string {
+	if name, prs := kindNames[k]; prs {
+		return name
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// kindPreference orders the kinds a column may be inferred as when they
+// cover the same number of values.  A column of "0" and "1" is an int
+// column, and a column of "$1" and "2" is a money column.  Identifiers
+// such as ISBNs are preferred to the ints they also parse as, and so are
+// phone numbers, coordinates and codes such as ZIP codes.  Strings come
+// last, so a kind wins a tie with the values that parse as nothing.
+var kindPreference = []Kind{
+	KindIdentifier,
+	KindPhone,
+	KindCoordinate,
+	KindRange,
+	KindList,
+	KindCode,
+	KindMoney,
+	KindInt,
+	KindFloat,
+	KindTime,
+	KindQuantity,
+	KindNetwork,
+	KindEmail,
+	KindURL,
+	KindBool,
+	KindString,
+}
+
+// layoutNames are the names of the time package's layout constants.
+var layoutNames = map[string]string{
+	time.ANSIC:       "ANSIC",
+	time.UnixDate:    "UnixDate",
+	time.RubyDate:    "RubyDate",
+	time.RFC822:      "RFC822",
+	time.RFC822Z:     "RFC822Z",
+	time.RFC850:      "RFC850",
+	time.RFC1123:     "RFC1123",
+	time.RFC1123Z:    "RFC1123Z",
+	time.RFC3339:     "RFC3339",
+	time.RFC3339Nano: "RFC3339Nano",
+	time.Kitchen:     "Kitchen",
+	time.Stamp:       "Stamp",
+	time.StampMilli:  "StampMilli",
+	time.StampMicro:  "StampMicro",
+	time.StampNano:   "StampNano",
+}
+
+// A ColumnType summarizes the values observed in a column, such as a
+// spreadsheet column or the values at a path in a set of JSON documents.
+type ColumnType struct {
+	Name string
+	Kind Kind
+	// Count is the number of non-null values and Nulls the number of
+	// null values observed.  Matches is the number of non-null values
+	// that are of the column's Kind.
+	Count   int
+	Nulls   int
+	Matches int
+	// Currency is the most common currency symbol of a money column.
+	Currency string
+	// Layout is the most common layout of a time column.
+	Layout string
+	// Unit is the most common unit symbol of a quantity column.
+	Unit string
+	// Decimals is the largest number of decimal places of a numeric
+	// column's values.
+	Decimals int
+	// Width is the number of digits of a code column's values when they
+	// all have the same number of digits, and 0 otherwise.
+	Width int
+}
+
+// Coverage is the fraction of the non-null values that are of the
+// column's Kind.  An empty column has coverage 1.
+func (c ColumnType) Coverage() float64 {
+	if c.Count == 0 {
+		return 1
+	}
+	return float64(c.Matches) / float64(c.Count)
+}
+
+// Nullable reports whether the column contains null values.
+func (c ColumnType) Nullable() bool {
+	return c.Nulls > 0
+}
+
+// String describes the column type, e.g. "money $, 98% coverage" or
+// "time layout RFC3339, 100% coverage, nullable".
+func (c ColumnType) String() string {
+	desc := c.Kind.String()
+	switch {
+	case c.Kind == KindMoney && c.Currency != "":
+		desc += " " + strings.TrimSpace(c.Currency)
+	case c.Kind == KindTime && c.Layout != "":
+		layout := c.Layout
+		if name, prs := layoutNames[layout]; prs {
+			layout = name
+		}
+		desc += " layout " + layout
+	case c.Kind == KindQuantity && c.Unit != "":
+		desc += " " + c.Unit
+	case c.Kind == KindCode && c.Width != 0:
+		desc += fmt.Sprintf(" width %d", c.Width)
+	}
+	desc += fmt.Sprintf(", %d%% coverage", int(math.Floor(100*c.Coverage())))
+	if c.Nullable() {
+		desc += ", nullable"
+	}
+	return desc
+}
+
+// A Column infers the type of a column of values by parsing each of them
+// and counting the kinds they represent.
+type Column struct {
+	name       string
+	parser     *Parser
+	count      int
+	nulls      int
+	kinds      map[Kind]int
+	currencies map[string]int
+	layouts    map[string]int
+	units      map[string]int
+	widths     map[int]int
+	codes      int
+	money      int
+	decimals   int
+}
+
+// NewColumn returns an empty Column that parses values with the given
+// Parser, or with the general purpose Parser if p is nil.
+func NewColumn(name string, p *Parser) *Column {
+	if p == nil {
+		p = context.p
+	}
+	return &Column{
+		name:       name,
+		parser:     p,
+		kinds:      make(map[Kind]int),
+		currencies: make(map[string]int),
+		layouts:    make(map[string]int),
+		units:      make(map[string]int),
+		widths:     make(map[int]int),
+	}
+}
+
+// Add parses a value and records its kinds.
+func (c *Column) Add(s string) {
+	parsed, err := c.parser.ParseType(s)
+	if err != nil {
+		c.count++
+		c.kinds[KindString]++
+		return
+	}
+	c.AddParsed(parsed)
+}
+
+// AddNull records a null value.
+func (c *Column) AddNull() {
+	c.nulls++
+}
+
+// AddParsed records the kinds of a value that has already been parsed.
+// Values of no other kind count as strings.
+func (c *Column) AddParsed(p *Parsed) {
+	c.count++
+	if p.Kind() == KindString {
+		c.kinds[KindString]++
+	}
+	if p.IsNumeric() {
+		c.kinds[KindFloat]++
+		if p.IsInt() {
+			c.kinds[KindInt]++
+		}
+		if p.IsMoney() {
+			c.currencies[p.format.currency]++
+			c.money++
+		}
+		if p.format.decimals > c.decimals {
+			c.decimals = p.format.decimals
+		}
+		if p.IsDigitString() {
+			c.kinds[KindCode]++
+			c.widths[p.Width()]++
+		}
+		if p.IsCode() {
+			c.codes++
+		}
+	}
+	if p.IsTime() {
+		c.kinds[KindTime]++
+		c.layouts[p.layout]++
+	}
+	if p.IsQuantity() {
+		c.kinds[KindQuantity]++
+		c.units[p.quantity.Unit.Symbol]++
+	}
+	if p.IsIdentifier() {
+		c.kinds[KindIdentifier]++
+	}
+	if p.IsPhone() {
+		c.kinds[KindPhone]++
+	}
+	if p.IsCoordinate() {
+		c.kinds[KindCoordinate]++
+	}
+	if p.IsRange() {
+		c.kinds[KindRange]++
+	}
+	if p.IsList() {
+		c.kinds[KindList]++
+	}
+	if p.IsNetwork() {
+		c.kinds[KindNetwork]++
+	}
+	if p.IsEmail() {
+		c.kinds[KindEmail]++
+	}
+	if p.IsURL() {
+		c.kinds[KindURL]++
+	}
+	if p.IsBool() {
+		c.kinds[KindBool]++
+	}
+}
+
+// Type returns the kind that covers the most values observed so far,
+// breaking ties in favor of identifier, phone, coordinate, range, list,
+// code, money, int, float, time, quantity, network, email, URL and bool,
+// in that order.  Numeric values without a currency symbol count
+// towards a money column once at least half of the numeric values have
+// one, and digit strings count towards a code column once any of them
+// is a code, as "02134" is.  Values that parse as nothing count
+// towards a string column, so a column of names with a stray "Y" is a
+// string column rather than a bool one.
+func (c *Column) Type() ColumnType {
+	ct := ColumnType{
+		Name:     c.name,
+		Kind:     KindString,
+		Count:    c.count,
+		Nulls:    c.nulls,
+		Matches:  c.count,
+		Currency: mostCommon(c.currencies),
+		Layout:   mostCommon(c.layouts),
+		Unit:     mostCommon(c.units),
+		Decimals: c.decimals,
+	}
+	if len(c.widths) == 1 {
+		for w := range c.widths {
+			ct.Width = w
+		}
+	}
+
+	best := 0
+	for _, k := range kindPreference {
+		n := c.kinds[k]
+		switch {
+		case k == KindMoney && c.money > 0 && 2*c.money >= c.kinds[KindFloat]:
+			n = c.kinds[KindFloat]
+		case k == KindMoney, k == KindCode && c.codes == 0:
+			n = 0
+		}
+		if n > best {
+			best = n
+			ct.Kind = k
+			ct.Matches = n
+		}
+	}
+	return ct
+}
+
+// mostCommon returns the key with the largest count, breaking ties in
+// favor of the lexically smallest key.
+func mostCommon(m map[string]int) string {
+	var key string
+	best := 0
+	for k, n := range m {
+		if n > best || (n == best && k < key) {
+			key, best = k, n
+		}
+	}
+	return key
+}
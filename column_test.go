@@ -0,0 +1,86 @@
+package multiparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsedKind(t *testing.T) {
+	tests := []struct {
+		in  string
+		out Kind
+	}{
+		{"$12", KindMoney},
+		{"12", KindInt},
+		{"1", KindInt},
+		{"12.5", KindFloat},
+		{"00123", KindCode},
+		{"1234567890123456", KindCode},
+		{"2015-01-02", KindTime},
+		{"yes", KindBool},
+	}
+
+	for _, tt := range tests {
+		p, err := ParseType(tt.in)
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.out, p.Kind(), tt.in)
+	}
+	assert.Equal(t, KindString, NewParsed().Kind())
+	assert.Equal(t, "money", KindMoney.String())
+	assert.Equal(t, "Kind(100)", Kind(100).String())
+}
+
+func TestColumnType(t *testing.T) {
+	tests := []struct {
+		in   []string
+		kind Kind
+		desc string
+	}{
+		{[]string{"1", "2", "3"}, KindInt, "int, 100% coverage"},
+		{[]string{"0", "1", "yes"}, KindBool, "bool, 100% coverage"},
+		{[]string{"1", "2.25", "abc"}, KindFloat, "float, 66% coverage"},
+		{[]string{"$1", "$2.50", "3"}, KindMoney, "money $, 100% coverage"},
+		{[]string{"USD 1", "USD 2", "$3"}, KindMoney, "money USD, 100% coverage"},
+		{[]string{"$1", "2", "3", "4"}, KindInt, "int, 100% coverage"},
+		{[]string{"$1", "$2", "3", "4"}, KindMoney, "money $, 100% coverage"},
+		{[]string{"2015-01-02T00:00:00Z", "2015-01-03T10:00:00Z"}, KindTime, "time layout RFC3339, 100% coverage"},
+		{[]string{"2015-01-02", "2015-01-03"}, KindTime, "time layout 2006-01-02, 100% coverage"},
+		{[]string{"abc", "def"}, KindString, "string, 100% coverage"},
+		{[]string{"alice", "bob", "carol", "dave", "Y"}, KindString, "string, 80% coverage"},
+		{[]string{"1", "abc"}, KindInt, "int, 50% coverage"},
+		{[]string{"02134", "90210", "10001"}, KindCode, "code width 5, 100% coverage"},
+		{[]string{"007", "12", "1234"}, KindCode, "code, 100% coverage"},
+		{[]string{"2019", "2020", "2021"}, KindInt, "int, 100% coverage"},
+		{[]string{"007", "-12", "1.5"}, KindFloat, "float, 100% coverage"},
+	}
+
+	for _, tt := range tests {
+		c := NewColumn("x", nil)
+		for _, s := range tt.in {
+			c.Add(s)
+		}
+		ct := c.Type()
+		assert.Equal(t, tt.kind, ct.Kind, tt.in)
+		assert.Equal(t, tt.desc, ct.String(), tt.in)
+		assert.Equal(t, "x", ct.Name)
+	}
+}
+
+func TestColumnTypeNullsAndDecimals(t *testing.T) {
+	c := NewColumn("price", NewUSDParser())
+	c.Add("$1,234.5")
+	c.Add("$2.125")
+	c.AddNull()
+	ct := c.Type()
+	assert.Equal(t, KindMoney, ct.Kind)
+	assert.Equal(t, 3, ct.Decimals)
+	assert.Equal(t, 2, ct.Count)
+	assert.Equal(t, 1, ct.Nulls)
+	assert.True(t, ct.Nullable())
+	assert.Equal(t, "money $, 100% coverage, nullable", ct.String())
+
+	empty := NewColumn("empty", nil).Type()
+	assert.Equal(t, KindString, empty.Kind)
+	assert.Equal(t, 1.0, empty.Coverage())
+}
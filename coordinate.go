@@ -0,0 +1,247 @@
+package multiparse
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A Coordinate is a geographic position in decimal degrees.  A string
+// such as "N 37 46.493" gives only a latitude, and "122°25′9.8″W" only
+// a longitude.
+type Coordinate struct {
+	Latitude     float64
+	Longitude    float64
+	HasLatitude  bool
+	HasLongitude bool
+}
+
+// coordinateSymbols replaces the degree, minute and second symbols with
+// the letters d, m and s.
+var coordinateSymbols = strings.NewReplacer(
+	"°", "d", "º", "d", "˚", "d",
+	"″", "s", "\"", "s", "”", "s", "''", "s", "′′", "s",
+	"′", "m", "'", "m", "’", "m",
+)
+
+// coordinatePartRegex matches degrees, optionally followed by minutes
+// and seconds, after symbols have been replaced by coordinateSymbols.
+var coordinatePartRegex = regexp.MustCompile("^(\\d+(?:\\.\\d+)?)\\s*(d)?\\s*(?:(\\d+(?:\\.\\d+)?)\\s*(m)?\\s*(?:(\\d+(?:\\.\\d+)?)\\s*(s)?)?)?$")
+
+// A coordinatePart is one half of a coordinate, such as "37.7749",
+// "37°46′29.6″N" or "N 37 46.493".
+type coordinatePart struct {
+	value      float64
+	hemisphere rune // 'N', 'S', 'E', 'W' or 0
+	marked     bool // written with a hemisphere or degree symbols
+}
+
+// A CoordinateParser determines whether a string is a geographic
+// coordinate, written in decimal degrees ("37.7749,-122.4194"), degrees,
+// minutes and seconds ("37°46′29.6″N 122°25′9.8″W") or degrees and
+// decimal minutes ("N 37 46.493").  Latitudes must be between -90 and 90
+// degrees and longitudes between -180 and 180 degrees.  To avoid
+// reading plain numbers as coordinates, a single latitude or longitude
+// must have a hemisphere letter, and the halves of a pair of decimal
+// degrees must both have decimal places.  Hemisphere letters must be
+// upper case unless the string has degree symbols, so that "30 s" is
+// not a coordinate.
+type CoordinateParser struct{}
+
+// NewCoordinateParser returns a CoordinateParser.
+func NewCoordinateParser() *CoordinateParser {
+	return &CoordinateParser{}
+}
+
+// Parse a string to determine if it is a coordinate.  The returned
+// value is a *Coordinate instance.
+func (p CoordinateParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParseCoordinate is the same as Parse but returns a *Coordinate
+// instance.
+func (p CoordinateParser) ParseCoordinate(s string) (*Coordinate, error) {
+	return p.parse(s)
+}
+
+func (p CoordinateParser) parse(s string) (*Coordinate, error) {
+	parseErr := errors.New(ParseCoordinateError)
+
+	s = strings.TrimSpace(s)
+	if halves := splitCoordinates(s); len(halves) == 2 {
+		if c, ok := coordinatePair(halves[0], halves[1]); ok {
+			return c, nil
+		}
+	}
+
+	part, ok := parseCoordinatePart(s)
+	if !ok || part.hemisphere == 0 {
+		return nil, parseErr
+	}
+	c := &Coordinate{}
+	if !c.set(part) {
+		return nil, parseErr
+	}
+	return c, nil
+}
+
+// set records a latitude or longitude according to the part's
+// hemisphere, reporting false if it is out of range.
+func (c *Coordinate) set(part coordinatePart) bool {
+	switch part.hemisphere {
+	case 'N', 'S':
+		c.Latitude, c.HasLatitude = part.value, true
+		return math.Abs(part.value) <= 90
+	case 'E', 'W':
+		c.Longitude, c.HasLongitude = part.value, true
+		return math.Abs(part.value) <= 180
+	}
+	return false
+}
+
+// coordinatePair parses a latitude and longitude, in either order if
+// their hemispheres are given.
+func coordinatePair(a, b string) (*Coordinate, bool) {
+	first, ok1 := parseCoordinatePart(a)
+	second, ok2 := parseCoordinatePart(b)
+	if !ok1 || !ok2 {
+		return nil, false
+	}
+	if !first.marked && !second.marked &&
+		(!strings.Contains(a, ".") || !strings.Contains(b, ".")) {
+		return nil, false
+	}
+
+	if first.hemisphere == 0 {
+		first.hemisphere = 'N'
+		if second.hemisphere == 'N' || second.hemisphere == 'S' {
+			first.hemisphere = 'E'
+		}
+	}
+	if second.hemisphere == 0 {
+		second.hemisphere = 'E'
+		if first.hemisphere == 'E' || first.hemisphere == 'W' {
+			second.hemisphere = 'N'
+		}
+	}
+
+	c := &Coordinate{}
+	if !c.set(first) || !c.set(second) || !c.HasLatitude || !c.HasLongitude {
+		return nil, false
+	}
+	return c, true
+}
+
+// isHemisphere reports whether the rune at index i of s is a hemisphere
+// letter that is not part of a longer word.  Lower case letters are only
+// hemispheres in strings with degree symbols, since the "s" of "30 s" is
+// more likely seconds.
+func isHemisphere(runes []rune, i int) bool {
+	letters := "NSEW"
+	if strings.ContainsAny(string(runes), "°º˚") {
+		letters = "NSEWnsew"
+	}
+	if !strings.ContainsRune(letters, runes[i]) {
+		return false
+	}
+	return (i == 0 || !unicode.IsLetter(runes[i-1])) &&
+		(i == len(runes)-1 || !unicode.IsLetter(runes[i+1]))
+}
+
+// splitCoordinates splits a pair of coordinates at a comma or semicolon,
+// between two hemisphere letters, or at the space between two decimal
+// numbers.
+func splitCoordinates(s string) []string {
+	for _, sep := range []string{";", ","} {
+		if strings.Count(s, sep) == 1 {
+			i := strings.Index(s, sep)
+			return []string{s[:i], s[i+1:]}
+		}
+	}
+
+	runes := []rune(s)
+	var letters []int
+	for i := range runes {
+		if isHemisphere(runes, i) {
+			letters = append(letters, i)
+		}
+	}
+	if len(letters) == 2 {
+		// Split before the second letter if hemispheres are written
+		// first, as in "N 37 46.493 W 122 25.163", and after the first
+		// letter otherwise.
+		i := letters[0] + 1
+		if letters[0] == 0 {
+			i = letters[1]
+		}
+		return []string{string(runes[:i]), string(runes[i:])}
+	}
+
+	return strings.Fields(s)
+}
+
+// parseCoordinatePart parses a latitude or longitude with an optional
+// sign or hemisphere letter.
+func parseCoordinatePart(s string) (coordinatePart, bool) {
+	var part coordinatePart
+
+	s = strings.TrimSpace(s)
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return part, false
+	}
+	switch {
+	case isHemisphere(runes, 0):
+		part.hemisphere = unicode.ToUpper(runes[0])
+		s = string(runes[1:])
+	case isHemisphere(runes, len(runes)-1):
+		part.hemisphere = unicode.ToUpper(runes[len(runes)-1])
+		s = string(runes[:len(runes)-1])
+	}
+	s = strings.TrimSpace(s)
+
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		if part.hemisphere != 0 {
+			return part, false
+		}
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	m := coordinatePartRegex.FindStringSubmatch(coordinateSymbols.Replace(s))
+	if m == nil {
+		return part, false
+	}
+	deg, _ := strconv.ParseFloat(m[1], 64)
+	part.value = deg
+	if m[3] != "" {
+		min, _ := strconv.ParseFloat(m[3], 64)
+		if strings.Contains(m[1], ".") || min >= 60 {
+			return part, false
+		}
+		part.value += min / 60
+	}
+	if m[5] != "" {
+		sec, _ := strconv.ParseFloat(m[5], 64)
+		if strings.Contains(m[3], ".") || sec >= 60 {
+			return part, false
+		}
+		part.value += sec / 3600
+	}
+	// Minutes and seconds without a hemisphere need symbols, so that
+	// "37 46" is not a coordinate.
+	part.marked = part.hemisphere != 0 || m[2] != "" || m[4] != "" || m[6] != ""
+	if m[3] != "" && !part.marked {
+		return part, false
+	}
+
+	if negative || part.hemisphere == 'S' || part.hemisphere == 'W' {
+		part.value = -part.value
+	}
+	return part, true
+}
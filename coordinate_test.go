@@ -0,0 +1,86 @@
+package multiparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoordinateParserParse(t *testing.T) {
+	tests := []struct {
+		in             string
+		lat, lon       float64
+		hasLat, hasLon bool
+	}{
+		{"37.7749,-122.4194", 37.7749, -122.4194, true, true},
+		{"37.7749, -122.4194", 37.7749, -122.4194, true, true},
+		{"-33.8688 151.2093", -33.8688, 151.2093, true, true},
+		{"37.7749;-122.4194", 37.7749, -122.4194, true, true},
+		{"37°46′29.6″N 122°25′9.8″W", 37.7749, -122.41939, true, true},
+		{"37°46'29.6\"N, 122°25'9.8\"W", 37.7749, -122.41939, true, true},
+		{"122°25′9.8″W 37°46′29.6″N", 37.7749, -122.41939, true, true},
+		{"N 37 46.493 W 122 25.163", 37.77488, -122.41938, true, true},
+		{"37.7749° N, 122.4194° W", 37.7749, -122.4194, true, true},
+		{"33.8688S 151.2093E", -33.8688, 151.2093, true, true},
+		{"N 37 46.493", 37.77488, 0, true, false},
+		{"37°46.493′N", 37.77488, 0, true, false},
+		{"122°25′9.8″W", 0, -122.41939, false, true},
+		{"12.5° s", -12.5, 0, true, false},
+		{"37.7749° n, 122.4194° w", 37.7749, -122.4194, true, true},
+	}
+
+	p := NewCoordinateParser()
+	for _, tt := range tests {
+		c, err := p.ParseCoordinate(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.InDelta(t, tt.lat, c.Latitude, 1e-4, tt.in)
+		assert.InDelta(t, tt.lon, c.Longitude, 1e-4, tt.in)
+		assert.Equal(t, tt.hasLat, c.HasLatitude, tt.in)
+		assert.Equal(t, tt.hasLon, c.HasLongitude, tt.in)
+	}
+
+	fails := []string{
+		"", "37.7749", "1,234", "10,20", "37 46", "37.5 46",
+		"91.0,0.0", "0.0,181.0", "N 91", "E 181", "N 37 61", "N 37 30 60",
+		"N -37", "N 37.5 30", "37°46′29.6″N 12°25′9.8″S", "North 37",
+		"1.5,2.5,3.5", "abc", "30 s", "5 w", "10 e", "s 12.5",
+		"37.7749 n, 122.4194 w",
+	}
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.EqualError(t, err, ParseCoordinateError, tt)
+	}
+}
+
+func TestParserRegisterCoordinateParser(t *testing.T) {
+	p := NewParser()
+	p.Register(NewCoordinateParser())
+
+	parsed, err := p.ParseType("37.7749,-122.4194")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsCoordinate())
+	assert.False(t, parsed.IsNumeric())
+	assert.Equal(t, KindCoordinate, parsed.Kind())
+	assert.InDelta(t, -122.4194, parsed.Coordinate().Longitude, 1e-9)
+
+	parsed, err = p.ParseType("37.7749")
+	assert.NoError(t, err)
+	assert.False(t, parsed.IsCoordinate())
+	assert.Equal(t, KindFloat, parsed.Kind())
+
+	q := NewParser()
+	q.Register(NewCoordinateParser(), NewQuantityParser())
+	parsed, err = q.ParseType("30 s")
+	assert.NoError(t, err)
+	assert.False(t, parsed.IsCoordinate())
+	assert.Equal(t, KindQuantity, parsed.Kind())
+
+	c := NewColumn("position", p)
+	for _, s := range []string{"37.7749,-122.4194", "40.7128,-74.0060", "n/a"} {
+		c.Add(s)
+	}
+	assert.Equal(t, "coordinate, 66% coverage", c.Type().String())
+}
@@ -0,0 +1,63 @@
+package multiparse
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+)
+
+// An Email is an email address, split into its parts.
+type Email struct {
+	// Address is the normalized address, with the domain in lower case.
+	Address string
+	Local   string
+	Domain  string
+	// Name is the display name of an address written as
+	// "Jane Doe <jane@example.com>", or "".
+	Name string
+}
+
+// An EmailParser determines whether a string is an email address, such
+// as "jane@example.com" or "Jane Doe <jane@example.com>".  The domain
+// must be a host name with at least two labels.
+type EmailParser struct{}
+
+// NewEmailParser returns an EmailParser.
+func NewEmailParser() *EmailParser {
+	return &EmailParser{}
+}
+
+// Parse a string to determine if it is an email address.  The returned
+// value is an *Email instance.
+func (p EmailParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParseEmail is the same as Parse but returns an *Email instance.
+func (p EmailParser) ParseEmail(s string) (*Email, error) {
+	return p.parse(s)
+}
+
+func (p EmailParser) parse(s string) (*Email, error) {
+	parseErr := errors.New(ParseEmailError)
+
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "@") {
+		return nil, parseErr
+	}
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return nil, parseErr
+	}
+	i := strings.LastIndex(addr.Address, "@")
+	local, domain := addr.Address[:i], strings.ToLower(addr.Address[i+1:])
+	if !hostnameRegex.MatchString(domain) || !strings.Contains(domain, ".") {
+		return nil, parseErr
+	}
+	return &Email{
+		Address: local + "@" + domain,
+		Local:   local,
+		Domain:  domain,
+		Name:    addr.Name,
+	}, nil
+}
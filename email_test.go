@@ -0,0 +1,45 @@
+package multiparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailParserParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		address string
+		local   string
+		domain  string
+		name    string
+	}{
+		{"jane@example.com", "jane@example.com", "jane", "example.com", ""},
+		{" Jane.Doe+tag@Example.COM ", "Jane.Doe+tag@example.com", "Jane.Doe+tag", "example.com", ""},
+		{"Jane Doe <jane@example.co.uk>", "jane@example.co.uk", "jane", "example.co.uk", "Jane Doe"},
+		{`"Doe, Jane" <jane@mail.example.org>`, "jane@mail.example.org", "jane", "mail.example.org", "Doe, Jane"},
+	}
+
+	p := NewEmailParser()
+	for _, tt := range tests {
+		e, err := p.ParseEmail(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tt.address, e.Address, tt.in)
+		assert.Equal(t, tt.local, e.Local, tt.in)
+		assert.Equal(t, tt.domain, e.Domain, tt.in)
+		assert.Equal(t, tt.name, e.Name, tt.in)
+	}
+
+	fails := []string{
+		"", "jane", "jane@", "@example.com", "jane@localhost",
+		"jane@exa mple.com", "jane@-example.com", "jane@@example.com",
+		"mailto:jane@example.com", "12.5",
+	}
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.EqualError(t, err, ParseEmailError, tt)
+	}
+}
@@ -2,15 +2,30 @@ package multiparse
 
 // Standard parsing errors.
 const (
-	ParseBoolError           = "Cannot parse string as a boolean."
-	ParseIntError            = "Cannot parse string as an integer."
-	ParseFloatError          = "Cannot parse string as a float."
-	ParseMonetaryStringError = "Cannot parse string as a monetary value."
-	ParseMoneyError          = ParseMonetaryStringError
-	ParseMoneySeparatorError = "Cannot distinguish digit and decimal separators."
-	ParseNumericError        = "Cannot parse string as a numeric type."
-	ParseTimeError           = "Cannot parse string as a time."
-	ParseTypeAssertError     = "Cannot assert correct type for parsed value."
-	ParseError               = "Cannot parse string as any valid type."
-	MoneyFloatError          = "Cannot convert Money instance to a float."
+	ParseByteSizeError           = "Cannot parse string as a byte size."
+	ParseByteSizeOverflowError   = "Byte size overflows a uint64."
+	ParseBoolError               = "Cannot parse string as a boolean."
+	ParseCoordinateError         = "Cannot parse string as a geographic coordinate."
+	ParseIdentifierError         = "Cannot parse string as an identifier."
+	ParseIntError                = "Cannot parse string as an integer."
+	ParseEmailError              = "Cannot parse string as an email address."
+	ParseFloatError              = "Cannot parse string as a float."
+	ParseListError               = "Cannot parse string as a list."
+	ParseMonetaryStringError     = "Cannot parse string as a monetary value."
+	ParseMoneyError              = ParseMonetaryStringError
+	ParseMoneySeparatorError     = "Cannot distinguish digit and decimal separators."
+	ParseAmbiguousSeparatorError = "Cannot tell whether separator groups digits or marks the decimal point."
+	ParseNetworkError            = "Cannot parse string as a network value."
+	ParseNumericError            = "Cannot parse string as a numeric type."
+	ParsePhoneError              = "Cannot parse string as a phone number."
+	ParseQuantityError           = "Cannot parse string as a physical quantity."
+	ParseRangeError              = "Cannot parse string as a range."
+	ParseRomanError              = "Cannot parse string as a Roman numeral."
+	ParseTimeError               = "Cannot parse string as a time."
+	ParseURLError                = "Cannot parse string as a URL."
+	ParseTypeAssertError         = "Cannot assert correct type for parsed value."
+	ParseError                   = "Cannot parse string as any valid type."
+	MoneyFloatError              = "Cannot convert Money instance to a float."
+	ScanNullError                = "Cannot scan a NULL value."
+	ScanTypeError                = "Cannot scan a value of this type."
 )
@@ -0,0 +1,308 @@
+package multiparse
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A Dialect is a flavor of SQL.
+type Dialect int
+
+// SQL dialects for which CreateTable can generate DDL.
+const (
+	DialectPostgres Dialect = iota
+	DialectSQLite
+	DialectMySQL
+)
+
+// moneyPrecision is the number of significant digits of the fixed point
+// type used for money columns.
+const moneyPrecision = 18
+
+// timeKind distinguishes date columns from timestamp columns with and
+// without a time zone, based on the column's time layout.  Columns with
+// an unknown layout are timestamps with a time zone.
+type timeKind int
+
+const (
+	timeDate timeKind = iota
+	timeStamp
+	timeStampZone
+)
+
+func columnTimeKind(layout string) timeKind {
+	switch {
+	case strings.Contains(layout, "Z07") || strings.Contains(layout, "-07") ||
+		strings.Contains(layout, "MST"):
+		return timeStampZone
+	case strings.Contains(layout, "15") || strings.Contains(layout, "3:04"):
+		return timeStamp
+	case layout == "":
+		return timeStampZone
+	}
+	return timeDate
+}
+
+// moneyScale returns the precision and scale of the fixed point type of
+// a money column.  The scale is the column's number of decimal places.
+func moneyScale(c ColumnType) (int, int) {
+	precision := moneyPrecision
+	if c.Decimals >= precision {
+		precision = c.Decimals + 1
+	}
+	return precision, c.Decimals
+}
+
+// sqlType returns the type of the column in the given SQL dialect.
+func sqlType(c ColumnType, d Dialect) string {
+	switch c.Kind {
+	case KindInt:
+		if d == DialectSQLite {
+			return "INTEGER"
+		}
+		return "BIGINT"
+	case KindFloat, KindQuantity:
+		switch d {
+		case DialectSQLite:
+			return "REAL"
+		case DialectMySQL:
+			return "DOUBLE"
+		}
+		return "DOUBLE PRECISION"
+	case KindMoney:
+		p, s := moneyScale(c)
+		switch d {
+		case DialectSQLite:
+			return "NUMERIC"
+		case DialectMySQL:
+			return fmt.Sprintf("DECIMAL(%d, %d)", p, s)
+		}
+		return fmt.Sprintf("NUMERIC(%d, %d)", p, s)
+	case KindTime:
+		tk := columnTimeKind(c.Layout)
+		switch {
+		case d == DialectSQLite:
+			return "TEXT"
+		case tk == timeDate:
+			return "DATE"
+		case d == DialectMySQL:
+			return "DATETIME"
+		case tk == timeStampZone:
+			return "TIMESTAMP WITH TIME ZONE"
+		}
+		return "TIMESTAMP"
+	case KindBool:
+		if d == DialectSQLite {
+			return "INTEGER"
+		}
+		return "BOOLEAN"
+	case KindCode:
+		if d != DialectSQLite && c.Width != 0 {
+			return fmt.Sprintf("CHAR(%d)", c.Width)
+		}
+	}
+	return "TEXT"
+}
+
+// quoteIdentifier quotes a table or column name for the SQL dialect.
+func quoteIdentifier(name string, d Dialect) string {
+	if d == DialectMySQL {
+		return "`" + strings.Replace(name, "`", "``", -1) + "`"
+	}
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+// plainNameRegex matches field names that schema formats accept as is.
+var plainNameRegex = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+// nameRunRegex matches the runs of characters that are replaced by an
+// underscore in Parquet field names.
+var nameRunRegex = regexp.MustCompile("[^A-Za-z0-9_]+")
+
+// arrowName quotes a field name for an Arrow schema description unless
+// it is a plain identifier.
+func arrowName(name string) string {
+	if plainNameRegex.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// parquetName returns a field name that the Parquet schema text format
+// accepts, which has no quoting, by replacing each run of other
+// characters with an underscore.  For example, "$.items[*].price" is
+// "items_price".  Names already in seen get a numeric suffix, and the
+// result is added to seen.
+func parquetName(name string, seen map[string]bool) string {
+	name = strings.Trim(nameRunRegex.ReplaceAllString(name, "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	unique := name
+	for i := 2; seen[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	seen[unique] = true
+	return unique
+}
+
+// CreateTable returns a CREATE TABLE statement for a table with the given
+// columns in the SQL dialect.  Columns without null values are declared
+// NOT NULL.  Quantity columns hold the magnitude of each value in the
+// column's Unit.  Money columns are fixed point with the column's number of
+// decimal places, time columns are dates or timestamps depending on
+// their layout, and code columns of a fixed width are CHAR columns.
+func CreateTable(name string, cols []ColumnType, d Dialect) string {
+	defs := make([]string, len(cols))
+	for i, c := range cols {
+		defs[i] = "  " + quoteIdentifier(c.Name, d) + " " + sqlType(c, d)
+		if !c.Nullable() {
+			defs[i] += " NOT NULL"
+		}
+	}
+	return "CREATE TABLE " + quoteIdentifier(name, d) + " (\n" +
+		strings.Join(defs, ",\n") + "\n);\n"
+}
+
+// JSONSchema returns a JSON Schema document describing objects with the
+// given columns as properties.  Columns without null values are required.
+func JSONSchema(cols []ColumnType) ([]byte, error) {
+	properties := make(map[string]interface{})
+	required := []string{}
+	for _, c := range cols {
+		prop := make(map[string]interface{})
+		var typ string
+		switch c.Kind {
+		case KindInt:
+			typ = "integer"
+		case KindFloat, KindMoney, KindQuantity:
+			typ = "number"
+		case KindBool:
+			typ = "boolean"
+		case KindEmail:
+			typ = "string"
+			prop["format"] = "email"
+		case KindURL:
+			typ = "string"
+			prop["format"] = "uri"
+		case KindCode:
+			typ = "string"
+			prop["pattern"] = "^[0-9]+$"
+			if c.Width != 0 {
+				prop["pattern"] = fmt.Sprintf("^[0-9]{%d}$", c.Width)
+			}
+		case KindTime:
+			typ = "string"
+			prop["format"] = "date-time"
+			if columnTimeKind(c.Layout) == timeDate {
+				prop["format"] = "date"
+			}
+		default:
+			typ = "string"
+		}
+		if c.Nullable() {
+			prop["type"] = []string{typ, "null"}
+		} else {
+			prop["type"] = typ
+			required = append(required, c.Name)
+		}
+		properties[c.Name] = prop
+	}
+
+	schema := map[string]interface{}{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// ArrowSchema returns a description of an Apache Arrow schema with the
+// given columns, in the format used by the Arrow Go implementation's
+// Schema.String method.  Column names other than plain identifiers are
+// quoted, as in "a \"note\"".
+func ArrowSchema(cols []ColumnType) string {
+	lines := []string{
+		"schema:",
+		fmt.Sprintf("  fields: %d", len(cols)),
+	}
+	for _, c := range cols {
+		var typ string
+		switch c.Kind {
+		case KindInt:
+			typ = "int64"
+		case KindFloat, KindQuantity:
+			typ = "float64"
+		case KindMoney:
+			p, s := moneyScale(c)
+			typ = fmt.Sprintf("decimal(%d, %d)", p, s)
+		case KindTime:
+			switch columnTimeKind(c.Layout) {
+			case timeDate:
+				typ = "date32"
+			case timeStamp:
+				typ = "timestamp[us]"
+			default:
+				typ = "timestamp[us, tz=UTC]"
+			}
+		case KindBool:
+			typ = "bool"
+		default:
+			typ = "utf8"
+		}
+		line := fmt.Sprintf("    - %s: type=%s", arrowName(c.Name), typ)
+		if c.Nullable() {
+			line += ", nullable"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// ParquetSchema returns an Apache Parquet message type definition with
+// the given columns, in the text format used by Parquet tools.  The
+// format cannot quote names, so characters other than letters, digits
+// and underscores are replaced, and "$.items[*].price" is written as
+// items_price.
+func ParquetSchema(name string, cols []ColumnType) string {
+	seen := make(map[string]bool)
+	lines := []string{"message " + parquetName(name, map[string]bool{}) + " {"}
+	for _, c := range cols {
+		var typ string
+		switch c.Kind {
+		case KindInt:
+			typ = "int64 %s"
+		case KindFloat, KindQuantity:
+			typ = "double %s"
+		case KindMoney:
+			p, s := moneyScale(c)
+			typ = fmt.Sprintf("int64 %%s (DECIMAL(%d,%d))", p, s)
+			if p > moneyPrecision {
+				typ = fmt.Sprintf("binary %%s (DECIMAL(%d,%d))", p, s)
+			}
+		case KindTime:
+			switch columnTimeKind(c.Layout) {
+			case timeDate:
+				typ = "int32 %s (DATE)"
+			case timeStamp:
+				typ = "int64 %s (TIMESTAMP(MICROS,false))"
+			default:
+				typ = "int64 %s (TIMESTAMP(MICROS,true))"
+			}
+		case KindBool:
+			typ = "boolean %s"
+		default:
+			typ = "binary %s (STRING)"
+		}
+		repetition := "required "
+		if c.Nullable() {
+			repetition = "optional "
+		}
+		lines = append(lines, "  "+repetition+fmt.Sprintf(typ, parquetName(c.Name, seen))+";")
+	}
+	return strings.Join(lines, "\n") + "\n}\n"
+}
@@ -0,0 +1,167 @@
+package multiparse
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func exportColumns() []ColumnType {
+	return []ColumnType{
+		{Name: "id", Kind: KindInt},
+		{Name: "rate", Kind: KindFloat, Nulls: 1},
+		{Name: "total", Kind: KindMoney, Currency: "$", Decimals: 2},
+		{Name: "created", Kind: KindTime, Layout: "2006-01-02T15:04:05Z07:00"},
+		{Name: "local", Kind: KindTime, Layout: "2006-01-02 15:04:05"},
+		{Name: "day", Kind: KindTime, Layout: "2006-01-02", Nulls: 2},
+		{Name: "paid", Kind: KindBool},
+		{Name: `a "note"`, Kind: KindString, Nulls: 1},
+	}
+}
+
+func TestCreateTable(t *testing.T) {
+	cols := exportColumns()
+
+	postgres := `CREATE TABLE "orders" (
+  "id" BIGINT NOT NULL,
+  "rate" DOUBLE PRECISION,
+  "total" NUMERIC(18, 2) NOT NULL,
+  "created" TIMESTAMP WITH TIME ZONE NOT NULL,
+  "local" TIMESTAMP NOT NULL,
+  "day" DATE,
+  "paid" BOOLEAN NOT NULL,
+  "a ""note""" TEXT
+);
+`
+	assert.Equal(t, postgres, CreateTable("orders", cols, DialectPostgres))
+
+	sqlite := `CREATE TABLE "orders" (
+  "id" INTEGER NOT NULL,
+  "rate" REAL,
+  "total" NUMERIC NOT NULL,
+  "created" TEXT NOT NULL,
+  "local" TEXT NOT NULL,
+  "day" TEXT,
+  "paid" INTEGER NOT NULL,
+  "a ""note""" TEXT
+);
+`
+	assert.Equal(t, sqlite, CreateTable("orders", cols, DialectSQLite))
+
+	mysql := "CREATE TABLE `orders` (\n" +
+		"  `id` BIGINT NOT NULL,\n" +
+		"  `rate` DOUBLE,\n" +
+		"  `total` DECIMAL(18, 2) NOT NULL,\n" +
+		"  `created` DATETIME NOT NULL,\n" +
+		"  `local` DATETIME NOT NULL,\n" +
+		"  `day` DATE,\n" +
+		"  `paid` BOOLEAN NOT NULL,\n" +
+		"  `a \"note\"` TEXT\n" +
+		");\n"
+	assert.Equal(t, mysql, CreateTable("orders", cols, DialectMySQL))
+}
+
+func TestJSONSchema(t *testing.T) {
+	b, err := JSONSchema(exportColumns())
+	assert.NoError(t, err)
+
+	var schema struct {
+		Type       string                            `json:"type"`
+		Properties map[string]map[string]interface{} `json:"properties"`
+		Required   []string                          `json:"required"`
+	}
+	assert.NoError(t, json.Unmarshal(b, &schema))
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []string{"id", "total", "created", "local", "paid"}, schema.Required)
+	assert.Equal(t, "integer", schema.Properties["id"]["type"])
+	assert.Equal(t, []interface{}{"number", "null"}, schema.Properties["rate"]["type"])
+	assert.Equal(t, "number", schema.Properties["total"]["type"])
+	assert.Equal(t, "date-time", schema.Properties["created"]["format"])
+	assert.Equal(t, "date", schema.Properties["day"]["format"])
+	assert.Equal(t, "boolean", schema.Properties["paid"]["type"])
+	assert.Equal(t, []interface{}{"string", "null"}, schema.Properties[`a "note"`]["type"])
+}
+
+func TestArrowSchema(t *testing.T) {
+	expected := `schema:
+  fields: 8
+    - id: type=int64
+    - rate: type=float64, nullable
+    - total: type=decimal(18, 2)
+    - created: type=timestamp[us, tz=UTC]
+    - local: type=timestamp[us]
+    - day: type=date32, nullable
+    - paid: type=bool
+    - "a \"note\"": type=utf8, nullable
+`
+	assert.Equal(t, expected, ArrowSchema(exportColumns()))
+}
+
+func TestParquetSchema(t *testing.T) {
+	expected := `message orders {
+  required int64 id;
+  optional double rate;
+  required int64 total (DECIMAL(18,2));
+  required int64 created (TIMESTAMP(MICROS,true));
+  required int64 local (TIMESTAMP(MICROS,false));
+  optional int32 day (DATE);
+  required boolean paid;
+  optional binary a_note (STRING);
+}
+`
+	assert.Equal(t, expected, ParquetSchema("orders", exportColumns()))
+}
+
+func TestExportInferredColumn(t *testing.T) {
+	c := NewColumn("price", NewUSDParser())
+	for _, s := range []string{"$1,234.5", "$2.125", "$3"} {
+		c.Add(s)
+	}
+	ddl := CreateTable("t", []ColumnType{c.Type()}, DialectPostgres)
+	assert.Equal(t, "CREATE TABLE \"t\" (\n  \"price\" NUMERIC(18, 3) NOT NULL\n);\n", ddl)
+}
+
+func TestExportCodeColumn(t *testing.T) {
+	zip := ColumnType{Name: "zip", Kind: KindCode, Width: 5}
+	sku := ColumnType{Name: "sku", Kind: KindCode}
+
+	assert.Equal(t, "CHAR(5)", sqlType(zip, DialectPostgres))
+	assert.Equal(t, "CHAR(5)", sqlType(zip, DialectMySQL))
+	assert.Equal(t, "TEXT", sqlType(zip, DialectSQLite))
+	assert.Equal(t, "TEXT", sqlType(sku, DialectPostgres))
+
+	b, err := JSONSchema([]ColumnType{zip, sku})
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"pattern": "^[0-9]{5}$"`)
+	assert.Contains(t, string(b), `"pattern": "^[0-9]+$"`)
+
+	assert.Contains(t, ArrowSchema([]ColumnType{zip}), "zip: type=utf8")
+	assert.Contains(t, ParquetSchema("m", []ColumnType{zip}), "required binary zip (STRING);")
+}
+
+func TestSchemaFieldNames(t *testing.T) {
+	cols := []ColumnType{
+		{Name: "$.items[*].price", Kind: KindFloat},
+		{Name: "$.items[*]price", Kind: KindFloat},
+		{Name: "2nd", Kind: KindInt},
+		{Name: "$", Kind: KindString},
+	}
+	expected := `message t_1 {
+  required double items_price;
+  required double items_price_2;
+  required int64 _2nd;
+  required binary _ (STRING);
+}
+`
+	assert.Equal(t, expected, ParquetSchema("t.1", cols))
+
+	expected = `schema:
+  fields: 4
+    - "$.items[*].price": type=float64
+    - "$.items[*]price": type=float64
+    - "2nd": type=int64
+    - "$": type=utf8
+`
+	assert.Equal(t, expected, ArrowSchema(cols))
+}
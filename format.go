@@ -0,0 +1,138 @@
+package multiparse
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Formatter renders values in the style of a previously parsed
+// string, so that parsed data can be transformed and written back in
+// its source format.  For example, a Formatter built from the parsed
+// "$1,234.50" renders 99.5 as "$99.50", and one built from the parsed
+// "03/04/2020" renders times with the "01/02/2006" layout.  Values are
+// scaled by the source's magnitude suffix, if any, so that one built
+// from "$1.2M" renders 3500000 as "$3.5M".  Integers are written as
+// Roman numerals when the source was one and they are in range.
+type Formatter struct {
+	format numberFormat
+	layout string
+}
+
+// NewFormatter returns a Formatter that uses the currency symbol,
+// separators, sign convention, decimal places and time layout detected
+// when the string was parsed.
+func NewFormatter(p *Parsed) *Formatter {
+	f := &Formatter{layout: p.layout}
+	if p.Numeric != nil {
+		f.format = p.Numeric.format
+	}
+	return f
+}
+
+// FormatFloat renders x with the source string's currency symbol, digit
+// grouping and decimal separator.  The value is rounded to the source's
+// number of decimal places.  If the source had no decimal places and x
+// is not integral, x is written with as many decimal places as needed.
+func (f Formatter) FormatFloat(x float64) string {
+	return f.format.render(x)
+}
+
+// FormatInt renders x with the source string's currency symbol and
+// digit grouping.  Unless the source had a magnitude suffix or was a
+// Roman numeral, x is written exactly, even beyond the 2^53 limit of
+// float64 precision.
+func (f Formatter) FormatInt(x int) string {
+	nf := f.format
+	if nf.magnitude != 0 || nf.roman != "" {
+		return nf.render(float64(x))
+	}
+	abs := uint64(x)
+	if x < 0 {
+		abs = -abs
+	}
+	digits := strconv.FormatUint(abs, 10)
+	if nf.decimals > 0 {
+		digits += "." + strings.Repeat("0", nf.decimals)
+	}
+	return nf.compose(x < 0, digits)
+}
+
+// FormatTime renders t with the source string's layout, or as RFC 3339
+// if the source was not a datetime.
+func (f Formatter) FormatTime(t time.Time) string {
+	if f.layout == "" {
+		return t.Format(time.RFC3339)
+	}
+	return t.Format(f.layout)
+}
+
+func (nf numberFormat) render(x float64) string {
+	if nf.magnitude != 0 {
+		x /= float64(nf.magnitude)
+	}
+	if nf.roman != "" && x == math.Trunc(x) {
+		if r := formatRoman(int(x)); r != "" {
+			if nf.roman == "i" {
+				r = strings.ToLower(r)
+			}
+			return r
+		}
+	}
+
+	prec := nf.decimals
+	if prec == 0 && x != math.Trunc(x) {
+		prec = -1
+	}
+	return nf.compose(x < 0, strconv.FormatFloat(math.Abs(x), 'f', prec, 64))
+}
+
+// compose renders the digits of an absolute value, with an optional
+// decimal point, with the format's sign, separators and symbols.
+func (nf numberFormat) compose(negative bool, digits string) string {
+	var sign string
+	switch {
+	case negative:
+		sign = "-"
+	case nf.sign == "+":
+		sign = "+"
+	}
+
+	intPart, fracPart := digits, ""
+	if i := strings.Index(digits, "."); i >= 0 {
+		intPart, fracPart = digits[:i], digits[i+1:]
+	}
+
+	if nf.digitSep != "" {
+		intPart = strings.Join(groupDigits(intPart, nf.indian), nf.digitSep)
+	}
+	if fracPart != "" {
+		decimalSep := nf.decimalSep
+		if decimalSep == "" {
+			decimalSep = "."
+			if nf.digitSep == "." {
+				decimalSep = ","
+			}
+		}
+		intPart += decimalSep + fracPart
+	}
+
+	return nf.currency + sign + intPart + nf.suffix
+}
+
+// groupDigits splits a string of digits into groups of three from the
+// right, or into a final group of three preceded by groups of two when
+// using Indian grouping.
+func groupDigits(s string, indian bool) []string {
+	size := 3
+	var groups []string
+	for len(s) > size {
+		groups = append([]string{s[len(s)-size:]}, groups...)
+		s = s[:len(s)-size]
+		if indian {
+			size = 2
+		}
+	}
+	return append([]string{s}, groups...)
+}
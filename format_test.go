@@ -0,0 +1,86 @@
+package multiparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFormatFloat(t *testing.T) {
+	tests := []struct {
+		source string
+		in     float64
+		out    string
+	}{
+		{"$1,000.00", 1234.5, "$1,234.50"},
+		{"$12.00", 1234.5, "$1234.50"},
+		{"$1,000", 1234567, "$1,234,567"},
+		{"$1,000", 1234.5, "$1,234.5"},
+		{"1.000,25", 1234567.891, "1.234.567,89"},
+		{"12,34,567", 98765432, "9,87,65,432"},
+		{"€3,5", -1234.5, "€-1234,5"},
+		{"+5", 7, "+7"},
+		{"+5", -7, "-7"},
+		{"USD 12", 3, "USD 3"},
+		{"0.125", 2, "2.000"},
+	}
+
+	p := NewCustomParser(NewCustomNumericParser("", "", ""), NewTimeParser(), NewBooleanParser())
+	for _, tt := range tests {
+		parsed, err := p.ParseType(tt.source)
+		assert.NoError(t, err, tt.source)
+		f := NewFormatter(parsed)
+		assert.Equal(t, tt.out, f.FormatFloat(tt.in), tt.source)
+	}
+}
+
+func TestFormatterFormatInt(t *testing.T) {
+	parsed, err := NewUSDParser().ParseType("$1,234.00")
+	assert.NoError(t, err)
+	assert.Equal(t, "$1,000,000.00", NewFormatter(parsed).FormatInt(1000000))
+	assert.Equal(t, "$-5.00", NewFormatter(parsed).FormatInt(-5))
+
+	parsed, err = Parse("1,234")
+	assert.NoError(t, err)
+	f := NewFormatter(parsed)
+	assert.Equal(t, "9,007,199,254,740,993", f.FormatInt(9007199254740993))
+	assert.Equal(t, "-9,007,199,254,740,993", f.FormatInt(-9007199254740993))
+	assert.Equal(t, "0", f.FormatInt(0))
+}
+
+func TestFormatterFormatTime(t *testing.T) {
+	tm := time.Date(2020, 3, 4, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		source string
+		out    string
+	}{
+		{"12/05/2019", "03/04/2020"},
+		{"1/25/2019", "3/4/2020"},
+		{"2019-12-25", "2020-03-04"},
+		{"2019-12-25T10:00:00Z", "2020-03-04T15:04:05Z"},
+		{"123", "2020-03-04T15:04:05Z"},
+	}
+
+	for _, tt := range tests {
+		parsed, err := Parse(tt.source)
+		assert.NoError(t, err, tt.source)
+		assert.Equal(t, tt.out, NewFormatter(parsed).FormatTime(tm), tt.source)
+	}
+}
+
+func TestTimeParserLayout(t *testing.T) {
+	p := NewTimeParser()
+	tm, err := p.ParseTimeLayout("2015-01-02")
+	assert.NoError(t, err)
+	assert.Equal(t, "2006-01-02", tm.Layout())
+	assert.Equal(t, 2015, tm.Year())
+
+	x, err := p.Parse("2015-01-02")
+	assert.NoError(t, err)
+	assert.IsType(t, time.Time{}, x)
+
+	parsed, err := Parse("2015-01-02")
+	assert.NoError(t, err)
+	assert.Equal(t, "2006-01-02", parsed.TimeLayout())
+}
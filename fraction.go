@@ -0,0 +1,121 @@
+package multiparse
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// vulgarFractions are the Unicode characters for common fractions.
+var vulgarFractions = map[string]*big.Rat{
+	"½": big.NewRat(1, 2),
+	"⅓": big.NewRat(1, 3),
+	"⅔": big.NewRat(2, 3),
+	"¼": big.NewRat(1, 4),
+	"¾": big.NewRat(3, 4),
+	"⅕": big.NewRat(1, 5),
+	"⅖": big.NewRat(2, 5),
+	"⅗": big.NewRat(3, 5),
+	"⅘": big.NewRat(4, 5),
+	"⅙": big.NewRat(1, 6),
+	"⅚": big.NewRat(5, 6),
+	"⅐": big.NewRat(1, 7),
+	"⅛": big.NewRat(1, 8),
+	"⅜": big.NewRat(3, 8),
+	"⅝": big.NewRat(5, 8),
+	"⅞": big.NewRat(7, 8),
+	"⅑": big.NewRat(1, 9),
+	"⅒": big.NewRat(1, 10),
+	"↉": big.NewRat(0, 3),
+}
+
+var (
+	// Matches "3/4", "-7/8" and "1 1/2", with either an ASCII slash or
+	// the Unicode fraction slash.  Exactly one slash is allowed, so that
+	// dates such as "1/2/06" are never fractions.
+	fractionRegex = regexp.MustCompile("^([+-])?(?:(\\d+)\\s+)?(\\d+)[/⁄](\\d+)$")
+	// Matches "½" and "2⅜".
+	vulgarFractionRegex = regexp.MustCompile("^([+-])?(\\d+)?\\s*(\\D)$")
+)
+
+// matchFraction splits a fraction or mixed number into its sign, whole
+// part and fractional part.
+func matchFraction(s string) (string, string, *big.Rat, bool) {
+	if m := fractionRegex.FindStringSubmatch(s); m != nil {
+		num, _ := new(big.Int).SetString(m[3], 10)
+		den, _ := new(big.Int).SetString(m[4], 10)
+		if den.Sign() == 0 {
+			return "", "", nil, false
+		}
+		return m[1], m[2], new(big.Rat).SetFrac(num, den), true
+	}
+	if m := vulgarFractionRegex.FindStringSubmatch(s); m != nil {
+		if r, prs := vulgarFractions[m[3]]; prs {
+			return m[1], m[2], new(big.Rat).Set(r), true
+		}
+	}
+	return "", "", nil, false
+}
+
+// parseFraction parses vulgar fractions and mixed numbers such as "3/4",
+// "1 1/2", "½" and "2⅜", possibly with a sign and currency symbol.  The
+// fractional part of a mixed number must be less than one.
+func (p NumericParser) parseFraction(s string) (*Numeric, string, error) {
+	parseErr := errors.New(ParseNumericError)
+
+	// The general currency pattern also matches characters such as "½",
+	// so only look for a currency symbol when s is not a bare fraction.
+	s = strings.TrimSpace(s)
+	var currency string
+	sign, whole, frac, ok := matchFraction(s)
+	if !ok && p.currencyRegex.MatchString(s) {
+		currency = p.currencyRegex.FindString(s)
+		sign, whole, frac, ok = matchFraction(p.removeCurrencySymbol(s))
+	}
+	if !ok {
+		return nil, "", parseErr
+	}
+
+	x := frac
+	if whole != "" {
+		if frac.Cmp(big.NewRat(1, 1)) >= 0 {
+			return nil, "", parseErr
+		}
+		x, _ = new(big.Rat).SetString(whole)
+		x.Add(x, frac)
+	}
+	if sign == "-" {
+		x.Neg(x)
+	}
+
+	f, _ := x.Float64()
+	n := &Numeric{
+		isInt:   x.IsInt(),
+		isFloat: true,
+		isMoney: currency != "",
+		f:       f,
+		rat:     x,
+		format:  numberFormat{currency: currency, sign: sign},
+	}
+	return n, x.RatString(), nil
+}
+
+// IsFraction reports if the original string is a fraction or mixed
+// number, such as "3/4" or "1 ½".
+func (x Numeric) IsFraction() bool {
+	return x.rat != nil
+}
+
+// Rat returns the value as an exact rational number.  For fractions this
+// is the exact value of the fraction, e.g. 1/3 for "⅓".  For decimal
+// numbers it is the shortest decimal that rounds to the float value,
+// e.g. 11/10 for "1.1".
+func (x Numeric) Rat() *big.Rat {
+	if x.rat != nil {
+		return new(big.Rat).Set(x.rat)
+	}
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(x.f, 'g', -1, 64))
+	return r
+}
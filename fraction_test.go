@@ -0,0 +1,103 @@
+package multiparse
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumericParserParseFraction(t *testing.T) {
+	tests := []struct {
+		in    string
+		rat   *big.Rat
+		f     float64
+		isInt bool
+		money bool
+	}{
+		{"3/4", big.NewRat(3, 4), 0.75, false, false},
+		{"-7/8", big.NewRat(-7, 8), -0.875, false, false},
+		{"+1/3", big.NewRat(1, 3), 1.0 / 3, false, false},
+		{"1 1/2", big.NewRat(3, 2), 1.5, false, false},
+		{"-1 1/2", big.NewRat(-3, 2), -1.5, false, false},
+		{"5/4", big.NewRat(5, 4), 1.25, false, false},
+		{"4/2", big.NewRat(2, 1), 2, true, false},
+		{"3⁄4", big.NewRat(3, 4), 0.75, false, false},
+		{"½", big.NewRat(1, 2), 0.5, false, false},
+		{"2⅜", big.NewRat(19, 8), 2.375, false, false},
+		{"2 ⅜", big.NewRat(19, 8), 2.375, false, false},
+		{"-⅓", big.NewRat(-1, 3), -1.0 / 3, false, false},
+		{"$12 3/8", big.NewRat(99, 8), 12.375, false, true},
+		{"$¾", big.NewRat(3, 4), 0.75, false, true},
+	}
+
+	p := NewNumericParser()
+	p.Fractions = true
+	for _, tt := range tests {
+		n, err := p.ParseNumeric(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.True(t, n.IsFraction(), tt.in)
+		assert.Equal(t, 0, tt.rat.Cmp(n.Rat()), tt.in)
+		assert.InDelta(t, tt.f, n.Float(), 1e-12, tt.in)
+		assert.Equal(t, tt.isInt, n.IsInt(), tt.in)
+		assert.Equal(t, tt.money, n.IsMoney(), tt.in)
+	}
+
+	fails := []string{"1/0", "1 5/4", "1/2/06", "1 1/2/3", "1/", "1½½", "a/b"}
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.Error(t, err, tt)
+	}
+
+	// Fractions are only recognized when enabled.
+	_, err := NewNumericParser().Parse("3/4")
+	assert.Error(t, err)
+
+	// Decimal numbers are unaffected.
+	n, err := p.ParseNumeric("1,234.5")
+	assert.NoError(t, err)
+	assert.False(t, n.IsFraction())
+	assert.Equal(t, 0, big.NewRat(2469, 2).Cmp(n.Rat()))
+}
+
+func TestNumericRat(t *testing.T) {
+	n, err := NewNumericParser().ParseNumeric("1.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "11/10", n.Rat().RatString())
+
+	// The returned rational is a copy.
+	p := NewNumericParser()
+	p.Fractions = true
+	n, err = p.ParseNumeric("⅓")
+	assert.NoError(t, err)
+	n.Rat().SetInt64(5)
+	assert.Equal(t, "1/3", n.Rat().RatString())
+}
+
+func TestParserFractionsAndDates(t *testing.T) {
+	n := NewNumericParser()
+	n.Fractions = true
+	p := NewCustomParser(n, NewTimeParser(), NewBooleanParser())
+
+	parsed, err := p.ParseType("1/2/06")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsTime())
+	assert.False(t, parsed.IsNumeric())
+
+	parsed, err = p.ParseType("1 1/2")
+	assert.NoError(t, err)
+	assert.False(t, parsed.IsTime())
+	assert.True(t, parsed.IsFraction())
+	assert.Equal(t, 1.5, parsed.Float())
+
+	// A layout that accepts a fraction is the more specific reading.
+	p = NewCustomParser(n, NewCustomTimeParser([]string{"1/2"}, nil), NewBooleanParser())
+	parsed, err = p.ParseType("3/4")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsTime())
+	assert.False(t, parsed.IsNumeric())
+	assert.False(t, parsed.IsFraction())
+}
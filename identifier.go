@@ -0,0 +1,267 @@
+package multiparse
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// An IdentifierKind is a kind of identifier.
+type IdentifierKind int
+
+// Kinds of identifiers.
+const (
+	IdentifierUUID IdentifierKind = iota // "123e4567-e89b-42d3-a456-426614174000"
+	IdentifierULID                       // "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	IdentifierISBN                       // "978-0-306-40615-7", "0-306-40615-2"
+	IdentifierIBAN                       // "GB82 WEST 1234 5698 7654 32"
+	IdentifierCard                       // "4111 1111 1111 1111"
+)
+
+var identifierKindNames = map[IdentifierKind]string{
+	IdentifierUUID: "uuid",
+	IdentifierULID: "ulid",
+	IdentifierISBN: "isbn",
+	IdentifierIBAN: "iban",
+	IdentifierCard: "card",
+}
+
+func (k IdentifierKind) String() string {
+	if name, prs := identifierKindNames[k]; prs {
+		return name
+	}
+	return fmt.Sprintf("IdentifierKind(%d)", int(k))
+}
+
+var (
+	uuidRegex = regexp.MustCompile("^(?i:urn:uuid:)?(\\{)?([0-9a-fA-F]{8})-([0-9a-fA-F]{4})-([0-9a-fA-F]{4})-([0-9a-fA-F]{4})-([0-9a-fA-F]{12})(\\})?$")
+	ulidRegex = regexp.MustCompile("^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$")
+	isbnRegex = regexp.MustCompile("^(?i:ISBN(?:-1[03])?:?\\s*)?([0-9][0-9 -]{8,15}[0-9Xx])$")
+	ibanRegex = regexp.MustCompile("^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$")
+	cardRegex = regexp.MustCompile("^[0-9]{4}(?:[ -]?[0-9]{1,4}){2,4}$")
+)
+
+// crockford is the Crockford base 32 alphabet of ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// An Identifier is a UUID, ULID, ISBN, IBAN or payment card number whose
+// format and checksum are valid.
+type Identifier struct {
+	Kind IdentifierKind
+	// Value is the identifier in its normal form: lower case for UUIDs,
+	// upper case for ULIDs and IBANs, and digits only, except for an
+	// ISBN-10's final "X", for ISBNs and card numbers.
+	Value string
+	// Version is the version of a UUID, or 10 or 13 for an ISBN.
+	Version int
+	// Time is the time encoded in a ULID.
+	Time time.Time
+}
+
+// An IdentifierParser determines whether a string is an identifier with
+// a valid checksum.  Each kind of identifier may be enabled separately.
+type IdentifierParser struct {
+	UUIDs bool
+	ULIDs bool
+	ISBNs bool
+	IBANs bool
+	Cards bool
+}
+
+// NewIdentifierParser returns an IdentifierParser that detects every
+// kind of identifier.
+func NewIdentifierParser() *IdentifierParser {
+	return &IdentifierParser{
+		UUIDs: true,
+		ULIDs: true,
+		ISBNs: true,
+		IBANs: true,
+		Cards: true,
+	}
+}
+
+// Parse a string to determine if it is an identifier.  The returned
+// value is an *Identifier instance.
+func (p IdentifierParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParseIdentifier is the same as Parse but returns an *Identifier
+// instance.
+func (p IdentifierParser) ParseIdentifier(s string) (*Identifier, error) {
+	return p.parse(s)
+}
+
+func (p IdentifierParser) parse(s string) (*Identifier, error) {
+	s = strings.TrimSpace(s)
+
+	checks := []struct {
+		enabled bool
+		parse   func(string) *Identifier
+	}{
+		{p.UUIDs, parseUUID},
+		{p.ULIDs, parseULID},
+		{p.ISBNs, parseISBN},
+		{p.IBANs, parseIBAN},
+		{p.Cards, parseCard},
+	}
+	for _, c := range checks {
+		if !c.enabled {
+			continue
+		}
+		if id := c.parse(s); id != nil {
+			return id, nil
+		}
+	}
+	return nil, errors.New(ParseIdentifierError)
+}
+
+// parseUUID accepts RFC 9562 UUIDs of versions 1 through 8, optionally
+// in braces or as a URN, and the nil UUID.
+func parseUUID(s string) *Identifier {
+	m := uuidRegex.FindStringSubmatch(s)
+	if m == nil || (m[1] == "") != (m[7] == "") {
+		return nil
+	}
+	value := strings.ToLower(strings.Join(m[2:7], "-"))
+	if value == "00000000-0000-0000-0000-000000000000" {
+		return &Identifier{Kind: IdentifierUUID, Value: value}
+	}
+
+	version := int(value[14] - '0')
+	variant := strings.IndexByte("89ab", value[19])
+	if version < 1 || version > 8 || variant < 0 {
+		return nil
+	}
+	return &Identifier{Kind: IdentifierUUID, Value: value, Version: version}
+}
+
+// parseULID accepts ULIDs and decodes their millisecond timestamps.
+// A ULID must have a letter, so that 26 digit numbers are not ULIDs.
+func parseULID(s string) *Identifier {
+	if !ulidRegex.MatchString(s) || !strings.ContainsAny(strings.ToUpper(s), crockford[10:]) {
+		return nil
+	}
+	value := strings.ToUpper(s)
+	var ms int64
+	for _, r := range value[:10] {
+		ms = ms<<5 | int64(strings.IndexRune(crockford, r))
+	}
+	return &Identifier{
+		Kind:  IdentifierULID,
+		Value: value,
+		Time:  time.UnixMilli(ms).UTC(),
+	}
+}
+
+// parseISBN accepts ISBN-10s with a valid mod 11 check digit and
+// ISBN-13s with a valid mod 10 check digit, optionally hyphenated and
+// prefixed with "ISBN".  One in eleven 10 digit numbers, such as phone
+// numbers, has a valid ISBN-10 check digit, so ISBN-10s must be
+// prefixed or grouped with hyphens or spaces, as in "0-306-40615-2".
+func parseISBN(s string) *Identifier {
+	m := isbnRegex.FindStringSubmatch(s)
+	if m == nil {
+		return nil
+	}
+	value := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(m[1]))
+	marked := len(m[1]) < len(s) || strings.ContainsAny(m[1], "- ")
+
+	switch len(value) {
+	case 10:
+		if !marked {
+			return nil
+		}
+		sum := 0
+		for i, r := range value {
+			d := int(r - '0')
+			if r == 'X' {
+				d = 10
+			}
+			sum += (10 - i) * d
+		}
+		if sum%11 != 0 {
+			return nil
+		}
+	case 13:
+		if strings.ContainsRune(value, 'X') ||
+			!(strings.HasPrefix(value, "978") || strings.HasPrefix(value, "979")) {
+			return nil
+		}
+		sum := 0
+		for i, r := range value {
+			d := int(r - '0')
+			if i%2 == 1 {
+				d *= 3
+			}
+			sum += d
+		}
+		if sum%10 != 0 {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return &Identifier{Kind: IdentifierISBN, Value: value, Version: len(value)}
+}
+
+// parseIBAN accepts IBANs, optionally written in groups of four, whose
+// check digits are valid under ISO 7064 mod 97-10.
+func parseIBAN(s string) *Identifier {
+	value := strings.ToUpper(strings.Replace(s, " ", "", -1))
+	if !ibanRegex.MatchString(value) {
+		return nil
+	}
+
+	// Move the country code and check digits to the end and replace
+	// letters with numbers, A = 10 to Z = 35.
+	var digits strings.Builder
+	for _, r := range value[4:] + value[:4] {
+		if r >= 'A' && r <= 'Z' {
+			fmt.Fprintf(&digits, "%d", r-'A'+10)
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	if n.Mod(n, big.NewInt(97)).Int64() != 1 {
+		return nil
+	}
+	return &Identifier{Kind: IdentifierIBAN, Value: value}
+}
+
+// parseCard accepts payment card numbers of 13 to 19 digits, optionally
+// grouped with spaces or hyphens, that begin with the digit of a major
+// card network and have a valid Luhn check digit.
+func parseCard(s string) *Identifier {
+	if !cardRegex.MatchString(s) {
+		return nil
+	}
+	value := strings.NewReplacer("-", "", " ", "").Replace(s)
+	if len(value) < 13 || len(value) > 19 || value[0] < '2' || value[0] > '6' || !luhnValid(value) {
+		return nil
+	}
+	return &Identifier{Kind: IdentifierCard, Value: value}
+}
+
+// luhnValid reports whether a string of digits has a valid Luhn check
+// digit.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
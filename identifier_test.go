@@ -0,0 +1,135 @@
+package multiparse
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentifierParserParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		kind    IdentifierKind
+		value   string
+		version int
+	}{
+		{"123e4567-e89b-42d3-a456-426614174000", IdentifierUUID, "123e4567-e89b-42d3-a456-426614174000", 4},
+		{"{123E4567-E89B-12D3-A456-426614174000}", IdentifierUUID, "123e4567-e89b-12d3-a456-426614174000", 1},
+		{"urn:uuid:0190a3f8-6c6e-7b1e-9d3e-2f1a4b5c6d7e", IdentifierUUID, "0190a3f8-6c6e-7b1e-9d3e-2f1a4b5c6d7e", 7},
+		{"00000000-0000-0000-0000-000000000000", IdentifierUUID, "00000000-0000-0000-0000-000000000000", 0},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", IdentifierULID, "01ARZ3NDEKTSV4RRFFQ69G5FAV", 0},
+		{"01arz3ndektsv4rrffq69g5fav", IdentifierULID, "01ARZ3NDEKTSV4RRFFQ69G5FAV", 0},
+		{"978-0-306-40615-7", IdentifierISBN, "9780306406157", 13},
+		{"9780306406157", IdentifierISBN, "9780306406157", 13},
+		{"ISBN 978-0-306-40615-7", IdentifierISBN, "9780306406157", 13},
+		{"0-306-40615-2", IdentifierISBN, "0306406152", 10},
+		{"ISBN-10: 0-8044-2957-X", IdentifierISBN, "080442957X", 10},
+		{"ISBN 080442957x", IdentifierISBN, "080442957X", 10},
+		{"0 306 40615 2", IdentifierISBN, "0306406152", 10},
+		{"GB82 WEST 1234 5698 7654 32", IdentifierIBAN, "GB82WEST12345698765432", 0},
+		{"DE89370400440532013000", IdentifierIBAN, "DE89370400440532013000", 0},
+		{"4111 1111 1111 1111", IdentifierCard, "4111111111111111", 0},
+		{"4111-1111-1111-1111", IdentifierCard, "4111111111111111", 0},
+		{"378282246310005", IdentifierCard, "378282246310005", 0},
+		{"5555555555554444", IdentifierCard, "5555555555554444", 0},
+	}
+
+	p := NewIdentifierParser()
+	for _, tt := range tests {
+		id, err := p.ParseIdentifier(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tt.kind, id.Kind, tt.in)
+		assert.Equal(t, tt.value, id.Value, tt.in)
+		assert.Equal(t, tt.version, id.Version, tt.in)
+	}
+
+	fails := []string{
+		"", "123",
+		"123e4567-e89b-02d3-a456-426614174000", // version 0
+		"123e4567-e89b-42d3-c456-426614174000", // variant
+		"{123e4567-e89b-42d3-a456-426614174000",
+		"81ARZ3NDEKTSV4RRFFQ69G5FAV", // overflows 48 bits
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU", // U is not in the alphabet
+		"978-0-306-40615-8",
+		"0-306-40615-3",
+		"X-306-40615-2",
+		"1234567890123", // ISBN-13 prefix
+		"0306406152",    // ISBN-10 without a prefix or hyphens
+		"080442957X",
+		"01234567890123456789012345", // ULID without a letter
+		"GB83 WEST 1234 5698 7654 32",
+		"4111 1111 1111 1112",
+		"1111 1111 1111 1117", // network digit
+		"4111 1111 111",
+	}
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.EqualError(t, err, ParseIdentifierError, tt)
+	}
+}
+
+func TestIdentifierParserPlainNumbers(t *testing.T) {
+	p := NewParser()
+	p.Register(NewIdentifierParser(), NewPhoneParser())
+	for n := 4155550000; n < 4155551100; n++ {
+		s := strconv.Itoa(n)
+		parsed, err := p.ParseType(s)
+		assert.NoError(t, err, s)
+		assert.False(t, parsed.IsIdentifier(), s)
+		assert.Equal(t, KindInt, parsed.Kind(), s)
+	}
+}
+
+func TestIdentifierParserULIDTime(t *testing.T) {
+	id, err := NewIdentifierParser().ParseIdentifier("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	assert.NoError(t, err)
+	assert.Equal(t, time.UnixMilli(1469922850259).UTC(), id.Time)
+}
+
+func TestIdentifierParserSelection(t *testing.T) {
+	p := &IdentifierParser{Cards: true}
+	_, err := p.Parse("978-0-306-40615-7")
+	assert.Error(t, err)
+	id, err := p.ParseIdentifier("4111111111111111")
+	assert.NoError(t, err)
+	assert.Equal(t, IdentifierCard, id.Kind)
+}
+
+func TestColumnPrefersIdentifiers(t *testing.T) {
+	p := NewParser()
+	p.Register(NewIdentifierParser())
+
+	parsed, err := p.ParseType("9780306406157")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsIdentifier())
+	assert.True(t, parsed.IsInt())
+	assert.Equal(t, KindIdentifier, parsed.Kind())
+	assert.Equal(t, IdentifierISBN, parsed.Identifier().Kind)
+
+	c := NewColumn("isbn", p)
+	for _, s := range []string{"9780306406157", "0-306-40615-2", "ISBN 080442957X"} {
+		c.Add(s)
+	}
+	assert.Equal(t, KindIdentifier, c.Type().Kind)
+
+	// A column of integers of which only some happen to pass a checksum
+	// remains an int column.
+	c = NewColumn("n", p)
+	for _, s := range []string{"4222222222222", "4222222222223", "4222222222224"} {
+		c.Add(s)
+	}
+	assert.Equal(t, KindInt, c.Type().Kind)
+
+	// Digit strings longer than DefaultMaxDigits are codes rather than
+	// integers, so such a column is a code column instead.
+	c = NewColumn("n", p)
+	for _, s := range []string{"4111111111111111", "4111111111111112", "4111111111111113"} {
+		c.Add(s)
+	}
+	assert.Equal(t, KindCode, c.Type().Kind)
+}
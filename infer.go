@@ -0,0 +1,158 @@
+package multiparse
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// A SchemaInferrer infers the types of the values in a set of JSON
+// documents, such as the lines of an NDJSON log.  Values are identified
+// by their path, e.g. "$.order.total" or "$.items[*].price".  String
+// values are parsed with the inferrer's Parser, while JSON numbers,
+// booleans and nulls are recorded as such.
+type SchemaInferrer struct {
+	parser  *Parser
+	columns map[string]*Column
+	records map[string]int // number of documents or array elements
+	present map[string]int // number of records with each path
+}
+
+// NewSchemaInferrer returns a SchemaInferrer that parses string values
+// with the given Parser, or with the general purpose Parser if p is nil.
+func NewSchemaInferrer(p *Parser) *SchemaInferrer {
+	if p == nil {
+		p = context.p
+	}
+	return &SchemaInferrer{
+		parser:  p,
+		columns: make(map[string]*Column),
+		records: make(map[string]int),
+		present: make(map[string]int),
+	}
+}
+
+// ReadJSON reads a stream of JSON documents, such as a single JSON
+// document or NDJSON, until EOF and records the values of each.
+func (s *SchemaInferrer) ReadJSON(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	for {
+		var doc interface{}
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.Add(doc)
+	}
+}
+
+// Add records the values of a JSON document decoded into an
+// interface{}, as by json.Unmarshal.
+func (s *SchemaInferrer) Add(doc interface{}) {
+	s.record("$", doc)
+}
+
+// record records the values of a record, which is a document or an
+// element of an array, and the paths present in it.
+func (s *SchemaInferrer) record(path string, v interface{}) {
+	seen := make(map[string]bool)
+	s.walk(path, v, seen)
+	s.records[path]++
+	for p := range seen {
+		s.present[p]++
+	}
+}
+
+// recordPath returns the path of the record a path belongs to: the
+// innermost array element, as "$.items[*]" is for "$.items[*].price",
+// or else the document.
+func recordPath(path string) string {
+	i := strings.LastIndex(path, "[*]")
+	if i < 0 {
+		return "$"
+	}
+	return path[:i+len("[*]")]
+}
+
+// walk records the values of v and the paths seen in the record.
+func (s *SchemaInferrer) walk(path string, v interface{}, seen map[string]bool) {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+	default:
+		seen[path] = true
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			s.walk(path+"."+k, child, seen)
+		}
+	case []interface{}:
+		for _, child := range t {
+			s.record(path+"[*]", child)
+		}
+	case nil:
+		s.column(path).AddNull()
+	case string:
+		s.column(path).Add(t)
+	default:
+		// Record JSON numbers that are integers as such, whether they
+		// were decoded as json.Number or float64 values.
+		switch n := t.(type) {
+		case json.Number:
+			if i, err := n.Int64(); err == nil {
+				v = i
+			} else if f, err := n.Float64(); err == nil {
+				v = f
+			}
+		case float64:
+			if n == math.Trunc(n) && math.Abs(n) < 1<<53 {
+				v = int64(n)
+			}
+		case int:
+			v = int64(n)
+		}
+		var x Value
+		if err := x.Scan(v); err != nil {
+			s.column(path).count++
+			return
+		}
+		s.column(path).AddParsed(x.Parsed)
+	}
+}
+
+func (s *SchemaInferrer) column(path string) *Column {
+	c, prs := s.columns[path]
+	if !prs {
+		c = NewColumn(path, s.parser)
+		s.columns[path] = c
+	}
+	return c
+}
+
+// Schema returns the inferred type of each path, sorted by path.  A
+// path that is missing from a record counts as a null value for that
+// record, as does an explicit JSON null.  Records are documents, except
+// for paths within arrays, such as "$.items[*].price", whose records are
+// the array elements, so that nulls are counted in the same units as
+// values.
+func (s *SchemaInferrer) Schema() []ColumnType {
+	paths := make([]string, 0, len(s.columns))
+	for path := range s.columns {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	schema := make([]ColumnType, len(paths))
+	for i, path := range paths {
+		schema[i] = s.columns[path].Type()
+		schema[i].Nulls += s.records[recordPath(path)] - s.present[path]
+	}
+	return schema
+}
@@ -0,0 +1,77 @@
+package multiparse
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaInferrerReadJSON(t *testing.T) {
+	ndjson := `{"order": {"total": "$12.50", "items": [{"qty": 1}, {"qty": 2}]}, "created": "2015-01-02T10:00:00Z", "paid": true, "note": null}
+{"order": {"total": "$3", "items": []}, "created": "2015-01-03T10:00:00Z", "paid": "no", "note": "rush"}
+{"order": {"total": "n/a", "items": [{"qty": 2.5}]}, "created": "2015-01-04T10:00:00Z", "paid": false, "note": "hold"}
+`
+	s := NewSchemaInferrer(nil)
+	assert.NoError(t, s.ReadJSON(strings.NewReader(ndjson)))
+	schema := s.Schema()
+
+	var paths []string
+	types := make(map[string]ColumnType)
+	for _, ct := range schema {
+		paths = append(paths, ct.Name)
+		types[ct.Name] = ct
+	}
+	assert.Equal(t, []string{"$.created", "$.note", "$.order.items[*].qty", "$.order.total", "$.paid"}, paths)
+
+	assert.Equal(t, "time layout RFC3339, 100% coverage", types["$.created"].String())
+	assert.Equal(t, "string, 100% coverage, nullable", types["$.note"].String())
+	assert.Equal(t, "float, 100% coverage", types["$.order.items[*].qty"].String())
+	assert.Equal(t, "money $, 66% coverage", types["$.order.total"].String())
+	assert.Equal(t, KindBool, types["$.paid"].Kind)
+
+	assert.Error(t, s.ReadJSON(strings.NewReader(`{"a": `)))
+}
+
+func TestSchemaInferrerAdd(t *testing.T) {
+	var doc interface{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"n": 12, "f": 1.5, "s": ["1", "2"]}`), &doc))
+	s := NewSchemaInferrer(NewParser())
+	s.Add(doc)
+	s.Add(map[string]interface{}{"n": 3})
+
+	schema := s.Schema()
+	assert.Len(t, schema, 3)
+	assert.Equal(t, "$.f", schema[0].Name)
+	assert.Equal(t, KindFloat, schema[0].Kind)
+	assert.Equal(t, 1, schema[0].Nulls)
+	assert.Equal(t, 0, schema[1].Nulls)
+	assert.Equal(t, KindInt, schema[1].Kind)
+	assert.Equal(t, 2, schema[1].Count)
+	assert.Equal(t, "$.s[*]", schema[2].Name)
+	assert.Equal(t, KindInt, schema[2].Kind)
+	assert.Equal(t, 0, schema[2].Nulls)
+
+	ddl := CreateTable("t", schema, DialectPostgres)
+	assert.Equal(t, "CREATE TABLE \"t\" (\n  \"$.f\" DOUBLE PRECISION,\n  \"$.n\" BIGINT NOT NULL,\n  \"$.s[*]\" BIGINT NOT NULL\n);\n", ddl)
+}
+
+func TestSchemaInferrerMissingPaths(t *testing.T) {
+	ndjson := `{"items": [{"qty": 1, "sku": "a"}, {"qty": 2}, {"qty": 3}], "order": {"id": 1}}
+{"items": [{"qty": 4, "sku": "b"}]}
+`
+	s := NewSchemaInferrer(nil)
+	assert.NoError(t, s.ReadJSON(strings.NewReader(ndjson)))
+
+	types := make(map[string]ColumnType)
+	for _, ct := range s.Schema() {
+		types[ct.Name] = ct
+	}
+	assert.Equal(t, 4, types["$.items[*].qty"].Count)
+	assert.Equal(t, 0, types["$.items[*].qty"].Nulls)
+	assert.Equal(t, 2, types["$.items[*].sku"].Count)
+	assert.Equal(t, 2, types["$.items[*].sku"].Nulls)
+	assert.Equal(t, 1, types["$.order.id"].Count)
+	assert.Equal(t, 1, types["$.order.id"].Nulls)
+}
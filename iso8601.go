@@ -0,0 +1,287 @@
+package multiparse
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Precision is the smallest unit a time string specifies, such as the
+// month of "2021-02" or the second of "2021-02-04T15:30:00Z".
+type Precision int
+
+// Precisions of parsed times, from the coarsest to the finest.
+// PrecisionUnknown is the precision of times returned by custom time
+// parsers as time.Time values.
+const (
+	PrecisionUnknown Precision = iota
+	PrecisionYear
+	PrecisionHalf
+	PrecisionQuarter
+	PrecisionMonth
+	PrecisionWeek
+	PrecisionDay
+	PrecisionHour
+	PrecisionMinute
+	PrecisionSecond
+	PrecisionFraction
+)
+
+var precisionNames = map[Precision]string{
+	PrecisionUnknown:  "unknown",
+	PrecisionYear:     "year",
+	PrecisionHalf:     "half",
+	PrecisionQuarter:  "quarter",
+	PrecisionMonth:    "month",
+	PrecisionWeek:     "week",
+	PrecisionDay:      "day",
+	PrecisionHour:     "hour",
+	PrecisionMinute:   "minute",
+	PrecisionSecond:   "second",
+	PrecisionFraction: "fraction",
+}
+
+func (p Precision) String() string {
+	if name, prs := precisionNames[p]; prs {
+		return name
+	}
+	return fmt.Sprintf("Precision(%d)", int(p))
+}
+
+// The groups of both ISO 8601 regular expressions are the year, month,
+// day, week, weekday, ordinal day, hour, minute, second, decimal fraction
+// and time zone.
+const (
+	// isoTime ends the time of day of both formats with an optional
+	// fraction and time zone.
+	isoTime = "(?:[.,](\\d+))?(Z|[+-]\\d{2}(?::?\\d{2})?)?)?$"
+
+	// isoExtended is the extended format, such as
+	// "2021-02-04T15:30:00Z", "2021-W05-3" and "2021-035", and dates of
+	// reduced precision such as "2021-02" and "2021".
+	isoExtended = "^(\\d{4})(?:-(\\d{2})(?:-(\\d{2}))?|-W(\\d{2})(?:-(\\d))?|-(\\d{3}))?" +
+		"(?:[T ](\\d{2})(?::(\\d{2})(?::(\\d{2}))?)?" + isoTime
+
+	// isoBasic is the basic format, such as
+	// "20210204T153000Z", "2021W053" and "2021035T1530".
+	isoBasic = "^(\\d{4})(?:(\\d{2})(\\d{2})|W(\\d{2})(\\d)?|(\\d{3}))" +
+		"(?:T(\\d{2})(?:(\\d{2})(\\d{2})?)?" + isoTime
+)
+
+var (
+	isoExtendedRegex = regexp.MustCompile(isoExtended)
+	isoBasicRegex    = regexp.MustCompile(isoBasic)
+)
+
+// Years written alone, such as "2021", must be from minDigitYear to
+// maxDigitYear, since most such strings are integers.
+const (
+	minDigitYear = 1900
+	maxDigitYear = 2100
+)
+
+// parseISO8601 parses a date or datetime in the ISO 8601 calendar, week
+// or ordinal date formats, in either the basic or extended format.
+// Dates may have reduced precision, as in "2021-02" and "2021", and the
+// smallest unit of a time may have a decimal fraction separated by a
+// period or comma, as in "15:30:00,123" and "15.5".  Times without a
+// zone are in UTC, and "24:00" is midnight at the end of the day.
+// Calendar and ordinal dates in the basic format need a time, as in
+// "20210204T1530", so that integers such as "20001231" and "1999123" are
+// not dates, and years written alone, such as "2021", are limited to
+// minDigitYear through maxDigitYear.
+//
+// The Time's layout is a Go layout that formats it the same way, or ""
+// when there is none, as for week dates and fractional hours.
+func parseISO8601(s string) (*Time, bool) {
+	basic := false
+	idx := isoExtendedRegex.FindStringSubmatchIndex(s)
+	if idx == nil {
+		if idx = isoBasicRegex.FindStringSubmatchIndex(s); idx == nil {
+			return nil, false
+		}
+		basic = true
+	}
+	m := make([]string, len(idx)/2)
+	for i := range m {
+		if idx[2*i] >= 0 {
+			m[i] = s[idx[2*i]:idx[2*i+1]]
+		}
+	}
+	num := func(i int) int {
+		n, _ := strconv.Atoi(m[i])
+		return n
+	}
+	// before returns the separator preceding group i, such as the "T"
+	// before the hour.
+	before := func(i int) string {
+		return s[idx[2*i]-1 : idx[2*i]]
+	}
+	month, day, week, weekday, ordinal := m[2], m[3], m[4], m[5], m[6]
+	hour, min, sec, frac, zone := m[7], m[8], m[9], m[10], m[11]
+
+	// Times of day need a complete date, and basic dates other than week
+	// dates need a time.
+	if hour != "" && day == "" && weekday == "" && ordinal == "" {
+		return nil, false
+	}
+	if basic && hour == "" && week == "" {
+		return nil, false
+	}
+
+	y := num(1)
+	if digitsOnly(s) == s && (y < minDigitYear || y > maxDigitYear) {
+		return nil, false
+	}
+	var date time.Time
+	var layout string
+	var prec Precision
+	sep := "-"
+	if basic {
+		sep = ""
+	}
+	switch {
+	case day != "":
+		date = time.Date(y, time.Month(num(2)), num(3), 0, 0, 0, 0, time.UTC)
+		if date.Month() != time.Month(num(2)) || date.Day() != num(3) {
+			return nil, false
+		}
+		layout, prec = "2006"+sep+"01"+sep+"02", PrecisionDay
+	case month != "":
+		if num(2) < 1 || num(2) > 12 {
+			return nil, false
+		}
+		date = time.Date(y, time.Month(num(2)), 1, 0, 0, 0, 0, time.UTC)
+		layout, prec = "2006-01", PrecisionMonth
+	case week != "":
+		d := 1
+		prec = PrecisionWeek
+		if weekday != "" {
+			d = num(5)
+			prec = PrecisionDay
+		}
+		if d < 1 || d > 7 {
+			return nil, false
+		}
+		// Week 1 is the week with the year's first Thursday, so it
+		// contains January 4.
+		jan4 := time.Date(y, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		date = monday.AddDate(0, 0, 7*(num(4)-1)+d-1)
+		if wy, w := date.ISOWeek(); wy != y || w != num(4) {
+			return nil, false
+		}
+	case ordinal != "":
+		date = time.Date(y, time.January, num(6), 0, 0, 0, 0, time.UTC)
+		if num(6) < 1 || date.Year() != y {
+			return nil, false
+		}
+		layout, prec = "2006"+sep+"002", PrecisionDay
+	default:
+		date = time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+		layout, prec = "2006", PrecisionYear
+	}
+
+	if hour == "" {
+		return &Time{Time: date, layout: layout, precision: prec}, true
+	}
+
+	h, mi, se := num(7), num(8), num(9)
+	if h > 24 || mi > 59 || se > 59 || (h == 24 && (mi != 0 || se != 0 || strings.Trim(frac, "0") != "")) {
+		return nil, false
+	}
+	layout += before(7) + "15"
+	unit := time.Hour
+	prec = PrecisionHour
+	if min != "" {
+		layout += strings.Replace(sep, "-", ":", 1) + "04"
+		unit, prec = time.Minute, PrecisionMinute
+	}
+	if sec != "" {
+		layout += strings.Replace(sep, "-", ":", 1) + "05"
+		unit, prec = time.Second, PrecisionSecond
+	}
+
+	offset := time.Duration(h)*time.Hour + time.Duration(mi)*time.Minute + time.Duration(se)*time.Second
+	if frac != "" {
+		x, _ := new(big.Rat).SetString("0." + frac)
+		x.Mul(x, new(big.Rat).SetInt64(int64(unit)))
+		ns, _ := x.Float64()
+		offset += time.Duration(ns)
+		prec = PrecisionFraction
+		switch {
+		case unit != time.Second:
+			layout = ""
+		case len(frac) <= 9:
+			layout += before(10) + strings.Repeat("0", len(frac))
+		default:
+			layout += before(10) + "999999999"
+		}
+	}
+
+	loc := time.UTC
+	if zone != "" && zone != "Z" {
+		zh, _ := strconv.Atoi(zone[1:3])
+		zm := 0
+		if digits := strings.Replace(zone[3:], ":", "", 1); digits != "" {
+			zm, _ = strconv.Atoi(digits)
+		}
+		if zh > 23 || zm > 59 {
+			return nil, false
+		}
+		secs := zh*3600 + zm*60
+		if zone[0] == '-' {
+			secs = -secs
+		}
+		loc = time.FixedZone("", secs)
+	}
+	if layout != "" {
+		switch {
+		case zone == "Z" && basic:
+			layout += "Z0700"
+		case zone == "Z":
+			layout += "Z07:00"
+		case len(zone) == 3:
+			layout += "Z07"
+		case strings.Contains(zone, ":"):
+			layout += "Z07:00"
+		case zone != "":
+			layout += "Z0700"
+		}
+	}
+	if week != "" {
+		layout = ""
+	}
+
+	t := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc).Add(offset)
+	if t.Year() > 9999 {
+		return nil, false
+	}
+	return &Time{Time: t, layout: layout, precision: prec}, true
+}
+
+// layoutPrecision returns the precision of times parsed with a Go
+// layout, which is the smallest unit the layout mentions.
+func layoutPrecision(layout string) Precision {
+	switch {
+	case strings.Contains(layout, "05.") || strings.Contains(layout, "05,"):
+		return PrecisionFraction
+	case strings.Contains(layout, "05"):
+		return PrecisionSecond
+	case strings.Contains(layout, "04"):
+		return PrecisionMinute
+	case strings.Contains(layout, "15"):
+		return PrecisionHour
+	}
+	date := strings.Replace(layout, "2006", "", -1)
+	switch {
+	case strings.Contains(date, "2"):
+		return PrecisionDay
+	case strings.Contains(date, "1") || strings.Contains(date, "Jan"):
+		return PrecisionMonth
+	}
+	return PrecisionYear
+}
@@ -0,0 +1,197 @@
+package multiparse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// FlexInt is an int that can be decoded from a JSON number or from a JSON
+// string such as "1,234" or "12.0".  It is encoded as a JSON number.
+type FlexInt int
+
+// FlexFloat is a float64 that can be decoded from a JSON number or from
+// a JSON string such as "1,234.50" or "$12".  It is encoded as a JSON
+// number.
+type FlexFloat float64
+
+// FlexBool is a bool that can be decoded from a JSON boolean, the JSON
+// numbers 0 and 1, or a JSON string such as "yes" or "FALSE".  It is
+// encoded as a JSON boolean.
+type FlexBool bool
+
+// jsonText returns the text of a JSON string or other literal, and
+// whether the literal is null.  Unmarshalers treat null as a no-op.
+func jsonText(data []byte) (string, bool, error) {
+	if bytes.Equal(data, []byte("null")) {
+		return "", true, nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		err := json.Unmarshal(data, &s)
+		return s, false, err
+	}
+	return string(data), false, nil
+}
+
+// jsonFloat decodes a JSON number, or a JSON string with the
+// package's general purpose parser.
+func jsonFloat(data []byte) (float64, bool, error) {
+	s, null, err := jsonText(data)
+	if err != nil || null {
+		return 0, null, err
+	}
+	if data[0] != '"' {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, false, errors.New(ParseFloatError)
+		}
+		return f, false, nil
+	}
+	f, err := context.p.ParseFloat(s)
+	return f, false, err
+}
+
+// jsonInt parses the text of a JSON number, or the sanitized text of a
+// number, as an integer.  Integers are parsed exactly, and numbers with
+// an exponent, such as 1e3, are accepted when they are integers that a
+// float64 represents exactly.
+func jsonInt(s string) (int, error) {
+	parseErr := errors.New(ParseIntError)
+	i, err := strconv.ParseInt(s, 10, 0)
+	if err == nil {
+		return int(i), nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, parseErr
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+		return 0, parseErr
+	}
+	return int(f), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.  Numbers
+// with a fractional part, such as 12.5, and numbers that overflow an
+// int are rejected.
+func (x *FlexInt) UnmarshalJSON(data []byte) error {
+	s, null, err := jsonText(data)
+	if err != nil || null {
+		return err
+	}
+	if data[0] == '"' {
+		n, err := context.p.ParseNumeric(s)
+		if err != nil {
+			return errors.New(ParseIntError)
+		}
+		s = n.Sanitized()
+	}
+	i, err := jsonInt(s)
+	if err != nil {
+		return err
+	}
+	*x = FlexInt(i)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (x FlexInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(x))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (x *FlexFloat) UnmarshalJSON(data []byte) error {
+	f, null, err := jsonFloat(data)
+	if err != nil || null {
+		return err
+	}
+	*x = FlexFloat(f)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (x FlexFloat) MarshalJSON() ([]byte, error) {
+	return json.Marshal(float64(x))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (x *FlexBool) UnmarshalJSON(data []byte) error {
+	s, null, err := jsonText(data)
+	if err != nil || null {
+		return err
+	}
+	b, err := context.p.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*x = FlexBool(b)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (x FlexBool) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bool(x))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.  Money can be
+// decoded from a JSON number or from a JSON string that may have a
+// currency symbol, such as "$1,234.50".
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s, null, err := jsonText(data)
+	if err != nil || null {
+		return err
+	}
+	if data[0] != '"' {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return errors.New(ParseMoneyError)
+		}
+		*m = Money{Amount: f}
+		return nil
+	}
+	x, err := parseMoney(context.p, s)
+	if err != nil {
+		return err
+	}
+	*m = x
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.  Money with a
+// currency symbol is encoded as a JSON string, such as "$12.5", and
+// money without one as a JSON number.
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m.Currency == "" {
+		return json.Marshal(m.Amount)
+	}
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.  The JSON
+// string may use any of the layouts the package's general purpose
+// Parser recognizes.
+func (t *FlexTime) UnmarshalJSON(data []byte) error {
+	s, null, err := jsonText(data)
+	if err != nil || null {
+		return err
+	}
+	if data[0] != '"' {
+		return errors.New(ParseTimeError)
+	}
+	x, err := context.p.ParseTime(s)
+	if err != nil {
+		return err
+	}
+	t.Time = x
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.  The time is
+// encoded as an RFC 3339 string.
+func (t FlexTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Time.Format(time.RFC3339Nano))
+}
@@ -0,0 +1,171 @@
+package multiparse
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlexIntJSON(t *testing.T) {
+	tests := []struct {
+		in  string
+		out FlexInt
+		err bool
+	}{
+		{`12`, 12, false},
+		{`"12"`, 12, false},
+		{`"1,234"`, 1234, false},
+		{`"12.0"`, 12, false},
+		{`1e3`, 1000, false},
+		{`12.5`, 0, true},
+		{`9007199254740993`, 9007199254740993, false},
+		{`"9007199254740993"`, 9007199254740993, false},
+		{`"9,007,199,254,740,993"`, 9007199254740993, false},
+		{`1e30`, 0, true},
+		{`"1e30"`, 0, true},
+		{`9223372036854775808`, 0, true},
+		{`"abc"`, 0, true},
+		{`true`, 0, true},
+	}
+
+	for _, tt := range tests {
+		var x FlexInt
+		err := json.Unmarshal([]byte(tt.in), &x)
+		if tt.err {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.out, x, tt.in)
+	}
+
+	b, err := json.Marshal(FlexInt(12))
+	assert.NoError(t, err)
+	assert.Equal(t, `12`, string(b))
+}
+
+func TestFlexFloatJSON(t *testing.T) {
+	tests := []struct {
+		in  string
+		out FlexFloat
+		err bool
+	}{
+		{`12`, 12, false},
+		{`"1,234.50"`, 1234.5, false},
+		{`"$12"`, 12, false},
+		{`"12.0"`, 12, false},
+		{`-0.5`, -0.5, false},
+		{`"abc"`, 0, true},
+		{`{}`, 0, true},
+	}
+
+	for _, tt := range tests {
+		var x FlexFloat
+		err := json.Unmarshal([]byte(tt.in), &x)
+		if tt.err {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.out, x, tt.in)
+	}
+
+	b, err := json.Marshal(FlexFloat(1.5))
+	assert.NoError(t, err)
+	assert.Equal(t, `1.5`, string(b))
+}
+
+func TestFlexBoolJSON(t *testing.T) {
+	tests := []struct {
+		in  string
+		out FlexBool
+		err bool
+	}{
+		{`true`, true, false},
+		{`false`, false, false},
+		{`1`, true, false},
+		{`"yes"`, true, false},
+		{`"FALSE"`, false, false},
+		{`"maybe"`, false, true},
+	}
+
+	for _, tt := range tests {
+		var x FlexBool
+		err := json.Unmarshal([]byte(tt.in), &x)
+		if tt.err {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.out, x, tt.in)
+	}
+
+	b, err := json.Marshal(FlexBool(true))
+	assert.NoError(t, err)
+	assert.Equal(t, `true`, string(b))
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		in  string
+		out Money
+	}{
+		{`"1,234.50"`, Money{Amount: 1234.5}},
+		{`"$12"`, Money{Amount: 12, Currency: "$"}},
+		{`12`, Money{Amount: 12}},
+		{`"12.0"`, Money{Amount: 12}},
+	}
+
+	for _, tt := range tests {
+		var m Money
+		assert.NoError(t, json.Unmarshal([]byte(tt.in), &m), tt.in)
+		assert.Equal(t, tt.out, m, tt.in)
+	}
+
+	var m Money
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &m))
+
+	b, err := json.Marshal(Money{Amount: 12.5, Currency: "$"})
+	assert.NoError(t, err)
+	assert.Equal(t, `"$12.5"`, string(b))
+	b, err = json.Marshal(Money{Amount: 12.5})
+	assert.NoError(t, err)
+	assert.Equal(t, `12.5`, string(b))
+}
+
+func TestFlexTimeJSON(t *testing.T) {
+	day := time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC)
+	for _, in := range []string{`"2015-01-02"`, `"01/02/2015"`, `"2015-01-02T00:00:00Z"`} {
+		var ft FlexTime
+		assert.NoError(t, json.Unmarshal([]byte(in), &ft), in)
+		assert.True(t, day.Equal(ft.Time), in)
+	}
+
+	var ft FlexTime
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &ft))
+	assert.Error(t, json.Unmarshal([]byte(`12`), &ft))
+
+	b, err := json.Marshal(FlexTime{day})
+	assert.NoError(t, err)
+	assert.Equal(t, `"2015-01-02T00:00:00Z"`, string(b))
+}
+
+func TestFlexJSONStruct(t *testing.T) {
+	var order struct {
+		Quantity FlexInt   `json:"quantity"`
+		Total    Money     `json:"total"`
+		Paid     FlexBool  `json:"paid"`
+		Created  FlexTime  `json:"created"`
+		Rate     FlexFloat `json:"rate"`
+	}
+	order.Quantity = 3
+	data := `{"quantity": null, "total": "$1,234.50", "paid": "Y", "created": "2015-01-02", "rate": "0.25"}`
+	assert.NoError(t, json.Unmarshal([]byte(data), &order))
+	assert.Equal(t, FlexInt(3), order.Quantity)
+	assert.Equal(t, 1234.5, order.Total.Amount)
+	assert.True(t, bool(order.Paid))
+	assert.Equal(t, 2015, order.Created.Year())
+	assert.Equal(t, FlexFloat(0.25), order.Rate)
+}
@@ -0,0 +1,169 @@
+package multiparse
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultListDelimiters are the delimiters a ListParser tries, in order.
+var DefaultListDelimiters = []string{";", "|", "\t", ","}
+
+// A List is a string of delimited values of the same kind, such as
+// "1;2;3", "red|green" or "[1, 2, 3]".
+type List struct {
+	// Elements are the values as written, without surrounding space.
+	Elements []string
+	// Values are the parsed elements.  Elements that do not parse are
+	// strings, as reported by Kind.
+	Values []*Parsed
+	// Kind is the kind of the elements.
+	Kind Kind
+	// Delimiter separates the elements.
+	Delimiter string
+}
+
+// A ListParser determines whether a string is a list of values of the
+// same kind by splitting it at each of its delimiters in turn and
+// parsing the elements with a Parser.  The elements are of the kind
+// a Column of them would be, and must all be of that kind, so "1;2.5"
+// is a list of floats but "1;red" is not a list.  A list may be
+// enclosed in brackets or braces, as in "[1, 2, 3]" and "{1,2,3}", and
+// must have at least two elements.
+//
+// Strings that parse as a number, such as "1,234", are not lists.
+// Otherwise the Parser's digit separator delimits elements, as in
+// "1,2,3", though elements followed by a space are tried first, so
+// "1,234, 5,678" is two numbers.  The decimal separator only delimits
+// elements when followed by a space or inside brackets.  Strings that do
+// not parse as any other kind are only lists when delimited by something
+// other than a comma or semicolon, as in "red|green", or enclosed in
+// brackets, so that prose such as "Hello, world" is not a list.
+type ListParser struct {
+	Delimiters []string
+	// Unexported fields.
+	parser *Parser
+}
+
+// NewListParser returns a ListParser that tries the
+// DefaultListDelimiters and parses elements with the given Parser, or
+// with the general purpose Parser if p is nil.
+func NewListParser(p *Parser) *ListParser {
+	return NewCustomListParser(p, DefaultListDelimiters)
+}
+
+// NewCustomListParser returns a ListParser that tries the given
+// delimiters and parses elements with the given Parser, or with the
+// general purpose Parser if p is nil.
+func NewCustomListParser(p *Parser, delimiters []string) *ListParser {
+	if p == nil {
+		p = context.p
+	}
+	return &ListParser{
+		Delimiters: delimiters,
+		parser:     p,
+	}
+}
+
+// Parse a string to determine if it is a list.  The returned value is a
+// *List instance.
+func (p ListParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParseList is the same as Parse but returns a *List instance.
+func (p ListParser) ParseList(s string) (*List, error) {
+	return p.parse(s)
+}
+
+// numericSeparator reports whether d groups digits or marks the decimal
+// point for the Parser's numeric parser, and whether it may mark the
+// decimal point.  Unspecified separators may be either "," or ".".
+func (p ListParser) numericSeparator(d string) (numeric, decimal bool) {
+	n, ok := p.parser.numeric.(*NumericParser)
+	if !ok {
+		return d == ",", d == ","
+	}
+	matches := func(sep string) bool {
+		return sep == d || (sep == "" && (d == "," || d == "."))
+	}
+	decimal = matches(n.DecimalSeparator)
+	return decimal || matches(n.DigitSeparator), decimal
+}
+
+func (p ListParser) parse(s string) (*List, error) {
+	parseErr := errors.New(ParseListError)
+
+	s = strings.TrimSpace(s)
+	if _, err := p.parser.numeric.Parse(s); err == nil {
+		return nil, parseErr
+	}
+
+	bracketed := false
+	if len(s) >= 2 && (s[0] == '[' && s[len(s)-1] == ']' || s[0] == '{' && s[len(s)-1] == '}') {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+		bracketed = true
+	}
+
+	for _, d := range p.Delimiters {
+		seps := []string{d}
+		if numeric, decimal := p.numericSeparator(d); numeric && !bracketed {
+			// "1,234, 5,678" is two numbers, and "1,5, 2,5" is two
+			// with a decimal comma.
+			seps = []string{d + " "}
+			if !decimal {
+				seps = append(seps, d)
+			}
+		}
+		for _, sep := range seps {
+			if !strings.Contains(s, sep) {
+				continue
+			}
+			if l := p.split(s, sep, bracketed); l != nil {
+				l.Delimiter = d
+				return l, nil
+			}
+		}
+	}
+	return nil, parseErr
+}
+
+// split splits s at sep, returning nil unless the elements are all of
+// the same kind.
+func (p ListParser) split(s, sep string, bracketed bool) *List {
+	elems := strings.Split(s, sep)
+	if len(elems) < 2 {
+		return nil
+	}
+
+	l := &List{
+		Elements: make([]string, len(elems)),
+		Values:   make([]*Parsed, len(elems)),
+	}
+	c := NewColumn("", p.parser)
+	for i, e := range elems {
+		e = strings.TrimSpace(e)
+		if bracketed && len(e) >= 2 && e[0] == '"' && e[len(e)-1] == '"' {
+			e = e[1 : len(e)-1]
+		}
+		if e == "" {
+			return nil
+		}
+		parsed, err := p.parser.ParseType(e)
+		if err != nil {
+			parsed = NewParsed()
+		}
+		l.Elements[i] = e
+		l.Values[i] = parsed
+		c.AddParsed(parsed)
+	}
+
+	ct := c.Type()
+	if ct.Matches != ct.Count {
+		return nil
+	}
+	if d := strings.TrimSpace(sep); ct.Kind == KindString && !bracketed && (d == "," || d == ";") {
+		return nil
+	}
+	l.Kind = ct.Kind
+	return l
+}
@@ -0,0 +1,97 @@
+package multiparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListParserParse(t *testing.T) {
+	tests := []struct {
+		in        string
+		elements  []string
+		kind      Kind
+		delimiter string
+	}{
+		{"1;2;3", []string{"1", "2", "3"}, KindInt, ";"},
+		{"1; 2.5; 3", []string{"1", "2.5", "3"}, KindFloat, ";"},
+		{"red|green", []string{"red", "green"}, KindString, "|"},
+		{"red\tgreen\tblue", []string{"red", "green", "blue"}, KindString, "\t"},
+		{"[1, 2, 3]", []string{"1", "2", "3"}, KindInt, ","},
+		{"[1,2,3]", []string{"1", "2", "3"}, KindInt, ","},
+		{"{1,2,3}", []string{"1", "2", "3"}, KindInt, ","},
+		{`["red", "green"]`, []string{"red", "green"}, KindString, ","},
+		{"2020-01-01, 2020-02-01", []string{"2020-01-01", "2020-02-01"}, KindTime, ","},
+		{"1,234, 5,678", []string{"1,234", "5,678"}, KindInt, ","},
+		{"$1;$2", []string{"$1", "$2"}, KindMoney, ";"},
+		{"true|false|true", []string{"true", "false", "true"}, KindBool, "|"},
+		{"1,2,3", []string{"1", "2", "3"}, KindInt, ","},
+		{"a, b|c, d", []string{"a, b", "c, d"}, KindString, "|"},
+		{"[a, b; c, d]", []string{"a, b", "c, d"}, KindString, ";"},
+	}
+
+	p := NewListParser(nil)
+	for _, tt := range tests {
+		l, err := p.ParseList(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tt.elements, l.Elements, tt.in)
+		assert.Equal(t, tt.kind, l.Kind, tt.in)
+		assert.Equal(t, tt.delimiter, l.Delimiter, tt.in)
+		assert.Len(t, l.Values, len(tt.elements), tt.in)
+	}
+
+	fails := []string{
+		"", "1", "1,234", "1,234,567", "$1,234.50", "2020-01-01",
+		"Hello, world", "Hello; world", "a, b; c, d", "red", "1;red",
+		"1;;2", "[]", "[1]", "1;", "a,b",
+	}
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.EqualError(t, err, ParseListError, tt)
+	}
+}
+
+func TestListParserDigitSeparator(t *testing.T) {
+	n := NewCustomNumericParser("", ".", ",")
+	p := NewListParser(NewCustomParser(n, NewTimeParser(), NewBooleanParser()))
+
+	l, err := p.ParseList("1,5,2,5")
+	assert.Error(t, err)
+	assert.Nil(t, l)
+
+	l, err = p.ParseList("1,5, 2,5")
+	assert.NoError(t, err)
+	assert.Equal(t, KindFloat, l.Kind)
+	assert.Equal(t, 2.5, l.Values[1].Float())
+
+	p = NewCustomListParser(nil, []string{"/"})
+	l, err = p.ParseList("a/b")
+	assert.NoError(t, err)
+	assert.Equal(t, "/", l.Delimiter)
+}
+
+func TestParserRegisterListParser(t *testing.T) {
+	p := NewParser()
+	p.Register(NewListParser(nil))
+
+	parsed, err := p.ParseType("2020-01-01, 2020-02-01")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsList())
+	assert.False(t, parsed.IsTime())
+	assert.Equal(t, KindList, parsed.Kind())
+	assert.Equal(t, KindTime, parsed.List().Kind)
+
+	parsed, err = p.ParseType("1,234")
+	assert.NoError(t, err)
+	assert.False(t, parsed.IsList())
+	assert.Equal(t, KindInt, parsed.Kind())
+
+	c := NewColumn("tags", p)
+	for _, s := range []string{"red|green", "blue|red", "none"} {
+		c.Add(s)
+	}
+	assert.Equal(t, "list, 66% coverage", c.Type().String())
+}
@@ -0,0 +1,283 @@
+package multiparse
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// A TimeLocale holds the words a language writes dates with, in lower
+// case.  The first name of each month and weekday is its full name and
+// the others are abbreviations or variant spellings.
+type TimeLocale struct {
+	// Months are the names of January through December.
+	Months [12][]string
+	// Weekdays are the names of Sunday through Saturday.
+	Weekdays [7][]string
+	// Fillers are words that join the parts of a date, such as the
+	// "de" of "4 de mayo de 2021".
+	Fillers []string
+	// Ordinals are the suffixes of day numbers, such as the "." of the
+	// German "3." and the "er" of the French "1er".
+	Ordinals []string
+}
+
+// TimeLocales maps language codes to the words dates are written with
+// in the language.  The table may be extended before parsers are
+// constructed.
+var TimeLocales = map[string]TimeLocale{
+	"en": {
+		Months: [12][]string{
+			{"january", "jan"}, {"february", "feb"}, {"march", "mar"},
+			{"april", "apr"}, {"may"}, {"june", "jun"}, {"july", "jul"},
+			{"august", "aug"}, {"september", "sep", "sept"},
+			{"october", "oct"}, {"november", "nov"}, {"december", "dec"},
+		},
+		Weekdays: [7][]string{
+			{"sunday", "sun"}, {"monday", "mon"}, {"tuesday", "tue", "tues"},
+			{"wednesday", "wed"}, {"thursday", "thu", "thurs"},
+			{"friday", "fri"}, {"saturday", "sat"},
+		},
+		Fillers:  []string{"of", "the"},
+		Ordinals: []string{"st", "nd", "rd", "th"},
+	},
+	"de": {
+		Months: [12][]string{
+			{"januar", "jan", "jänner"}, {"februar", "feb"},
+			{"märz", "mär", "maerz"}, {"april", "apr"}, {"mai"},
+			{"juni", "jun"}, {"juli", "jul"}, {"august", "aug"},
+			{"september", "sep", "sept"}, {"oktober", "okt"},
+			{"november", "nov"}, {"dezember", "dez"},
+		},
+		Weekdays: [7][]string{
+			{"sonntag", "so"}, {"montag", "mo"}, {"dienstag", "di"},
+			{"mittwoch", "mi"}, {"donnerstag", "do"}, {"freitag", "fr"},
+			{"samstag", "sa", "sonnabend"},
+		},
+		Fillers:  []string{"den"},
+		Ordinals: []string{"."},
+	},
+	"fr": {
+		Months: [12][]string{
+			{"janvier", "janv"}, {"février", "févr", "fevrier", "fevr"},
+			{"mars"}, {"avril", "avr"}, {"mai"}, {"juin"},
+			{"juillet", "juil"}, {"août", "aout"},
+			{"septembre", "sept"}, {"octobre", "oct"},
+			{"novembre", "nov"}, {"décembre", "déc", "decembre", "dec"},
+		},
+		Weekdays: [7][]string{
+			{"dimanche", "dim"}, {"lundi", "lun"}, {"mardi", "mar"},
+			{"mercredi", "mer"}, {"jeudi", "jeu"}, {"vendredi", "ven"},
+			{"samedi", "sam"},
+		},
+		Fillers:  []string{"le"},
+		Ordinals: []string{"er"},
+	},
+	"es": {
+		Months: [12][]string{
+			{"enero", "ene"}, {"febrero", "feb"}, {"marzo", "mar"},
+			{"abril", "abr"}, {"mayo", "may"}, {"junio", "jun"},
+			{"julio", "jul"}, {"agosto", "ago"},
+			{"septiembre", "setiembre", "sep", "sept", "set"},
+			{"octubre", "oct"}, {"noviembre", "nov"},
+			{"diciembre", "dic"},
+		},
+		Weekdays: [7][]string{
+			{"domingo", "dom"}, {"lunes", "lun"}, {"martes", "mar"},
+			{"miércoles", "mié", "miercoles", "mie"}, {"jueves", "jue"},
+			{"viernes", "vie"}, {"sábado", "sáb", "sabado", "sab"},
+		},
+		Fillers:  []string{"de", "del"},
+		Ordinals: []string{"º", "ª", "°"},
+	},
+	"it": {
+		Months: [12][]string{
+			{"gennaio", "gen"}, {"febbraio", "feb"}, {"marzo", "mar"},
+			{"aprile", "apr"}, {"maggio", "mag"}, {"giugno", "giu"},
+			{"luglio", "lug"}, {"agosto", "ago"}, {"settembre", "set"},
+			{"ottobre", "ott"}, {"novembre", "nov"}, {"dicembre", "dic"},
+		},
+		Weekdays: [7][]string{
+			{"domenica", "dom"}, {"lunedì", "lunedi", "lun"},
+			{"martedì", "martedi", "mar"}, {"mercoledì", "mercoledi", "mer"},
+			{"giovedì", "giovedi", "gio"}, {"venerdì", "venerdi", "ven"},
+			{"sabato", "sab"},
+		},
+		Ordinals: []string{"°", "º"},
+	},
+	"pt": {
+		Months: [12][]string{
+			{"janeiro", "jan"}, {"fevereiro", "fev"}, {"março", "marco", "mar"},
+			{"abril", "abr"}, {"maio", "mai"}, {"junho", "jun"},
+			{"julho", "jul"}, {"agosto", "ago"}, {"setembro", "set"},
+			{"outubro", "out"}, {"novembro", "nov"}, {"dezembro", "dez"},
+		},
+		Weekdays: [7][]string{
+			{"domingo", "dom"}, {"segunda-feira", "segunda", "seg"},
+			{"terça-feira", "terca-feira", "terça", "terca", "ter"},
+			{"quarta-feira", "quarta", "qua"},
+			{"quinta-feira", "quinta", "qui"},
+			{"sexta-feira", "sexta", "sex"}, {"sábado", "sabado", "sáb", "sab"},
+		},
+		Fillers:  []string{"de"},
+		Ordinals: []string{"º", "ª", "°"},
+	},
+	"nl": {
+		Months: [12][]string{
+			{"januari", "jan"}, {"februari", "feb"}, {"maart", "mrt"},
+			{"april", "apr"}, {"mei"}, {"juni", "jun"}, {"juli", "jul"},
+			{"augustus", "aug"}, {"september", "sep", "sept"},
+			{"oktober", "okt"}, {"november", "nov"}, {"december", "dec"},
+		},
+		Weekdays: [7][]string{
+			{"zondag", "zo"}, {"maandag", "ma"}, {"dinsdag", "di"},
+			{"woensdag", "wo"}, {"donderdag", "do"}, {"vrijdag", "vr"},
+			{"zaterdag", "za"},
+		},
+		Ordinals: []string{"e", "ste", "de"},
+	},
+}
+
+// NewLocalizedTimeParser returns a TimeParser like the one returned by
+// NewTimeParser that also accepts dates with month and weekday names in
+// the given languages of the TimeLocales table, such as "3. März 2021"
+// and "martes 4 de mayo de 2021".  The languages are tried in order, and
+// every language in the table is tried when none are given.
+func NewLocalizedTimeParser(locales ...string) *TimeParser {
+	if len(locales) == 0 {
+		for locale := range TimeLocales {
+			locales = append(locales, locale)
+		}
+		sort.Strings(locales)
+	}
+	p := NewTimeParser()
+	p.Locales = locales
+	return p
+}
+
+// Locale returns the language of the month and weekday names of the
+// string the time was parsed from, or "" if it was not parsed with a
+// TimeLocale.
+func (t Time) Locale() string {
+	return t.locale
+}
+
+// parseLocalized parses s with the first of the parser's locales that
+// accepts it.
+func (p TimeParser) parseLocalized(s string) *Time {
+	for _, locale := range p.Locales {
+		loc, prs := TimeLocales[locale]
+		if !prs {
+			continue
+		}
+		if t := loc.parse(s); t != nil {
+			t.locale = locale
+			return t
+		}
+	}
+	return nil
+}
+
+// lookup returns the index of the name in names, or -1.
+func lookup(names [][]string, name string) int {
+	for i, ns := range names {
+		for _, n := range ns {
+			if n == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// ordinal reports whether the suffix of a day number is empty or one of
+// the locale's Ordinals.
+func (l TimeLocale) ordinal(suffix string) bool {
+	for _, o := range l.Ordinals {
+		if suffix == o {
+			return true
+		}
+	}
+	return suffix == ""
+}
+
+// parse parses a date made of a month name, a four digit year, and
+// optionally a day number and weekday name, in any order, such as
+// "12 février 2021" or "martes 4 de mayo de 2021".  A date without a
+// day, such as "März 2021", is a partial date.  A weekday must match the
+// date.
+func (l TimeLocale) parse(s string) *Time {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+
+	var words []string
+	year, day := -1, -1
+	for _, f := range fields {
+		if f[0] >= '0' && f[0] <= '9' {
+			digits := strings.TrimRightFunc(f, func(r rune) bool { return !unicode.IsDigit(r) })
+			n, err := strconv.Atoi(digits)
+			switch {
+			case err != nil:
+				return nil
+			case len(digits) == 4 && digits == f && year < 0:
+				year = n
+			case len(digits) <= 2 && l.ordinal(f[len(digits):]) && day < 0:
+				day = n
+			default:
+				return nil
+			}
+			continue
+		}
+		f = strings.TrimSuffix(f, ".")
+		if f == "" {
+			continue
+		}
+		filler := false
+		for _, w := range l.Fillers {
+			filler = filler || f == w
+		}
+		if !filler {
+			words = append(words, f)
+		}
+	}
+	if year < 0 || len(words) == 0 || len(words) > 2 {
+		return nil
+	}
+
+	// A word that names both a month and a weekday, such as the Spanish
+	// "mar", is a weekday when another word names the month.
+	month, weekday := -1, -1
+	for i, w := range words {
+		m, wd := lookup(l.Months[:], w), lookup(l.Weekdays[:], w)
+		if m >= 0 && wd >= 0 && len(words) == 2 && lookup(l.Months[:], words[1-i]) >= 0 {
+			m = -1
+		}
+		switch {
+		case m >= 0 && month < 0:
+			month = m
+		case wd >= 0 && weekday < 0:
+			weekday = wd
+		default:
+			return nil
+		}
+	}
+	if month < 0 {
+		return nil
+	}
+
+	if day < 0 {
+		if weekday >= 0 {
+			return nil
+		}
+		t := time.Date(year, time.Month(month+1), 1, 0, 0, 0, 0, time.UTC)
+		return &Time{Time: t, precision: PrecisionMonth}
+	}
+	t := time.Date(year, time.Month(month+1), day, 0, 0, 0, 0, time.UTC)
+	if t.Day() != day || (weekday >= 0 && int(t.Weekday()) != weekday) {
+		return nil
+	}
+	return &Time{Time: t, precision: PrecisionDay}
+}
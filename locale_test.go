@@ -0,0 +1,85 @@
+package multiparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalizedTimeParser(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		in        string
+		out       time.Time
+		precision Precision
+		locale    string
+	}{
+		{"3. März 2021", date(2021, 3, 3), PrecisionDay, "de"},
+		{"Mittwoch, 3. März 2021", date(2021, 3, 3), PrecisionDay, "de"},
+		{"März 2021", date(2021, 3, 1), PrecisionMonth, "de"},
+		{"12 février 2021", date(2021, 2, 12), PrecisionDay, "fr"},
+		{"vendredi 12 février 2021", date(2021, 2, 12), PrecisionDay, "fr"},
+		{"1er mars 2021", date(2021, 3, 1), PrecisionDay, "fr"},
+		{"1er mai 2021", date(2021, 5, 1), PrecisionDay, "fr"},
+		{"1. Mai 2021", date(2021, 5, 1), PrecisionDay, "de"},
+		{"1º de maio de 2021", date(2021, 5, 1), PrecisionDay, "pt"},
+		{"martes 4 de mayo de 2021", date(2021, 5, 4), PrecisionDay, "es"},
+		{"mar 2 de marzo de 2021", date(2021, 3, 2), PrecisionDay, "es"},
+		{"4 maggio 2021", date(2021, 5, 4), PrecisionDay, "it"},
+		{"4 de maio de 2021", date(2021, 5, 4), PrecisionDay, "pt"},
+		{"terça-feira, 4 de maio de 2021", date(2021, 5, 4), PrecisionDay, "pt"},
+		{"4 mei 2021", date(2021, 5, 4), PrecisionDay, "nl"},
+		{"4th of May 2021", date(2021, 5, 4), PrecisionDay, "en"},
+		{"Tuesday, May 4, 2021", date(2021, 5, 4), PrecisionDay, "en"},
+	}
+
+	p := NewLocalizedTimeParser()
+	for _, tt := range tests {
+		tm, err := p.ParseTimeLayout(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tt.out, tm.Time, tt.in)
+		assert.Equal(t, tt.precision, tm.Precision(), tt.in)
+		assert.Equal(t, tt.locale, tm.Locale(), tt.in)
+	}
+
+	fails := []string{
+		"Donnerstag, 3. März 2021", "30 février 2021", "mayo", "4 mayo",
+		"4 5 mayo 2021", "martes mayo de 2021", "4 de foo de 2021",
+		"mayo junio 2021", "12345 mayo 2021", "4th mai 2021", "3er März 2021",
+	}
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.Error(t, err, tt)
+	}
+}
+
+func TestLocalizedTimeParserLocales(t *testing.T) {
+	// Only the selected locales are tried, in order.
+	p := NewLocalizedTimeParser("de")
+	_, err := p.Parse("12 février 2021")
+	assert.Error(t, err)
+
+	tm, err := p.ParseTimeLayout("4 mai 2021")
+	assert.NoError(t, err)
+	assert.Equal(t, "de", tm.Locale())
+
+	p = NewLocalizedTimeParser("fr", "de")
+	tm, err = p.ParseTimeLayout("4 mai 2021")
+	assert.NoError(t, err)
+	assert.Equal(t, "fr", tm.Locale())
+
+	_, err = NewTimeParser().Parse("3. März 2021")
+	assert.Error(t, err)
+
+	parser := NewCustomParser(NewNumericParser(), NewLocalizedTimeParser(), NewBooleanParser())
+	parsed, err := parser.ParseType("mai 2021")
+	assert.NoError(t, err)
+	assert.Equal(t, KindTime, parsed.Kind())
+	assert.True(t, parsed.IsPartialDate())
+}
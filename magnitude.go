@@ -0,0 +1,101 @@
+package multiparse
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// MagnitudeSuffixes maps the case-sensitive abbreviations of magnitude
+// words used in dashboards and finance exports, such as the "M" in
+// "$1.2M", to their values.
+var MagnitudeSuffixes = map[string]int64{
+	"k":  1e3,
+	"K":  1e3,
+	"M":  1e6,
+	"MM": 1e6,
+	"B":  1e9,
+	"bn": 1e9,
+	"T":  1e12,
+}
+
+// IndianMagnitudeSuffixes maps the lakh and crore, as in "10 lakh" and
+// "2 Cr", to their values.
+var IndianMagnitudeSuffixes = map[string]int64{
+	"lakh":   1e5,
+	"lakhs":  1e5,
+	"lac":    1e5,
+	"lacs":   1e5,
+	"crore":  1e7,
+	"crores": 1e7,
+	"Cr":     1e7,
+}
+
+// magnitudeSuffix returns the value of a suffix in either the
+// MagnitudeSuffixes or IndianMagnitudeSuffixes table.
+func magnitudeSuffix(s string) (int64, bool) {
+	if v, prs := MagnitudeSuffixes[s]; prs {
+		return v, true
+	}
+	v, prs := IndianMagnitudeSuffixes[s]
+	return v, prs
+}
+
+// parseSuffixed parses a number followed by one of the parser's
+// magnitude suffixes, possibly after a space, as in "1.2k" and
+// "10 lakh".  The value is computed exactly, so "1.2M" is the integer
+// 1200000.  The suffix is recorded as written so that other values can
+// be rendered with it.
+func (p NumericParser) parseSuffixed(s string) (*Numeric, string, error) {
+	parseErr := errors.New(ParseNumericError)
+
+	s = strings.TrimSpace(s)
+	var suffix string
+	var magnitude int64
+	for k, v := range p.Suffixes {
+		if len(k) > len(suffix) && strings.HasSuffix(s, k) {
+			suffix, magnitude = k, v
+		}
+	}
+	body := strings.TrimRight(s[:len(s)-len(suffix)], " ")
+	if suffix == "" || body == "" || !unicode.IsDigit(rune(body[len(body)-1])) {
+		return nil, "", parseErr
+	}
+
+	q := p
+	q.Suffixes = nil
+	n, text, err := q.parseText(body)
+	if err != nil {
+		return nil, "", err
+	}
+	x, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, "", parseErr
+	}
+	x.Mul(x, new(big.Rat).SetInt64(magnitude))
+
+	scaled := *n
+	scaled.f, _ = x.Float64()
+	scaled.isInt = x.IsInt()
+	if scaled.rat != nil {
+		scaled.rat = x
+	}
+	scaled.format.suffix = s[len(body):]
+	scaled.format.magnitude = magnitude
+
+	text = x.RatString()
+	if !n.IsFraction() {
+		text = decimalText(x, n.format.decimals)
+	}
+	return &scaled, text, nil
+}
+
+// decimalText returns the plain decimal text of x, which must have at
+// most the given number of decimal places.
+func decimalText(x *big.Rat, decimals int) string {
+	if x.IsInt() {
+		return x.RatString()
+	}
+	return strings.TrimRight(x.FloatString(decimals), "0")
+}
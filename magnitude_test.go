@@ -0,0 +1,110 @@
+package multiparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumericParserParseSuffixed(t *testing.T) {
+	tests := []struct {
+		in     string
+		f      float64
+		isInt  bool
+		money  bool
+		suffix string
+	}{
+		{"1.2k", 1200, true, false, "k"},
+		{"1.2K", 1200, true, false, "K"},
+		{"3M", 3e6, true, false, "M"},
+		{"3MM", 3e6, true, false, "MM"},
+		{"4.5bn", 4.5e9, true, false, "bn"},
+		{"4.5B", 4.5e9, true, false, "B"},
+		{"2T", 2e12, true, false, "T"},
+		{"1.0005k", 1000.5, false, false, "k"},
+		{"$1.2M", 1200000, true, true, "M"},
+		{"$-2.5k", -2500, true, true, "k"},
+		{"1,500K", 1500000, true, false, "K"},
+		{"10 lakh", 1e6, true, false, " lakh"},
+		{"₹2 crore", 2e7, true, true, " crore"},
+		{"2.5 Cr", 2.5e7, true, false, " Cr"},
+	}
+
+	p := NewNumericParser()
+	p.Suffixes = make(map[string]int64)
+	for k, v := range MagnitudeSuffixes {
+		p.Suffixes[k] = v
+	}
+	for k, v := range IndianMagnitudeSuffixes {
+		p.Suffixes[k] = v
+	}
+	for _, tt := range tests {
+		n, err := p.ParseNumeric(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tt.f, n.Float(), tt.in)
+		assert.Equal(t, tt.isInt, n.IsInt(), tt.in)
+		assert.Equal(t, tt.money, n.IsMoney(), tt.in)
+		assert.Equal(t, tt.suffix, n.format.suffix, tt.in)
+	}
+
+	fails := []string{"k", "1.2x", "1.2 kk", "1.2m", "12 lakhx", "1.2.3k"}
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.Error(t, err, tt)
+	}
+
+	// Plain numbers are unaffected.
+	n, err := p.ParseNumeric("1,234")
+	assert.NoError(t, err)
+	assert.Equal(t, 1234.0, n.Float())
+	assert.Equal(t, "", n.format.suffix)
+
+	// Suffixes are only recognized when configured.
+	_, err = NewNumericParser().Parse("1.2k")
+	assert.Error(t, err)
+}
+
+func TestNumericParserParseSuffixedText(t *testing.T) {
+	p := NewNumericParser()
+	p.Suffixes = MagnitudeSuffixes
+	tests := []struct {
+		in   string
+		text string
+	}{
+		{"1.2M", "1200000"},
+		{"-1.2M", "-1200000"},
+		{"1.2345k", "1234.5"},
+		{"0.0001k", "0.1"},
+	}
+	for _, tt := range tests {
+		_, text, err := p.parseText(tt.in)
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.text, text, tt.in)
+	}
+}
+
+func TestFormatterFormatFloatSuffixed(t *testing.T) {
+	n := NewNumericParser()
+	n.Suffixes = MagnitudeSuffixes
+	p := NewCustomParser(n, NewTimeParser(), NewBooleanParser())
+	tests := []struct {
+		source string
+		in     float64
+		out    string
+	}{
+		{"$1.2M", 3500000, "$3.5M"},
+		{"$1.2M", 3456789, "$3.5M"},
+		{"$1.2M", 1200000, "$1.2M"},
+		{"4bn", 2500000000, "2.5bn"},
+		{"10 k", 12000, "12 k"},
+	}
+	for _, tt := range tests {
+		parsed, err := p.ParseType(tt.source)
+		assert.NoError(t, err, tt.source)
+		f := NewFormatter(parsed)
+		assert.Equal(t, tt.out, f.FormatFloat(tt.in), tt.source)
+	}
+}
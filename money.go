@@ -1,49 +1,30 @@
 package multiparse
 
-// import (
-// 	"errors"
-// 	"math/big"
-// 	"strconv"
-// )
-
-// FIXME we don't need the money stuff.  It's overly nuanced?
+import (
+	"errors"
+	"strconv"
+)
 
 // A Money instance is a simple representation of a monetary value.  It
-// has access to the original pre-parsed string as well as a few numeric
-// types.
-// type Money struct {
-// 	original string
-// 	parsed   string
-// }
-
-// // String representation of the monetary value with any original currency
-// // symbols and formatting included.
-// func (m Money) String() string {
-// 	return m.original
-// }
-
-// // ParsedString returns a cleaner version of the original monetary string.
-// func (m Money) ParsedString() string {
-// 	return m.parsed
-// }
+// is not recommended that the Amount be used for accounting.
+type Money struct {
+	Amount float64
+	// Currency symbol as written in the original string, e.g. "$" or
+	// "USD ".  Empty if the string had no currency symbol.
+	Currency string
+}
 
-// // Float64 representation of the monetary value.  It is not
-// // recommended that this type be used for accounting.
-// func (m Money) Float64() (float64, error) {
-// 	f, err := strconv.ParseFloat(m.parsed, 64)
-// 	if err != nil {
-// 		err = errors.New(MoneyFloatError + err.Error())
-// 	}
-// 	return f, err
-// }
+// String representation of the monetary value with its currency symbol.
+func (m Money) String() string {
+	return m.Currency + strconv.FormatFloat(m.Amount, 'f', -1, 64)
+}
 
-// // BigFloat returns a big.Float representation of the monetary value.
-// // This type is more appropriate for accounting.
-// func (m Money) BigFloat() (*big.Float, error) {
-// 	tmp := new(big.Float)
-// 	bf, _, err := tmp.Parse(m.parsed, 10)
-// 	if err != nil {
-// 		err = errors.New(MoneyFloatError + err.Error())
-// 	}
-// 	return bf, err
-// }
+// parseMoney uses the parser to read a numeric string as a monetary
+// value.  Strings without a currency symbol are accepted as amounts.
+func parseMoney(p *Parser, s string) (Money, error) {
+	n, err := p.ParseNumeric(s)
+	if err != nil {
+		return Money{}, errors.New(ParseMoneyError)
+	}
+	return Money{Amount: n.Float(), Currency: n.format.currency}, nil
+}
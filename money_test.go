@@ -222,3 +222,18 @@ func TestParseMoney(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		in  Money
+		out string
+	}{
+		{Money{Amount: 12.5, Currency: "$"}, "$12.5"},
+		{Money{Amount: -3, Currency: "USD "}, "USD -3"},
+		{Money{Amount: 7}, "7"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.out, tt.in.String())
+	}
+}
@@ -0,0 +1,141 @@
+package multiparse
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A NetworkKind is a kind of network value.
+type NetworkKind int
+
+// Kinds of network values.
+const (
+	NetworkAddr     NetworkKind = iota // "10.0.0.1", "::1"
+	NetworkPrefix                      // "10.0.0.0/8"
+	NetworkHostPort                    // "10.0.0.1:80", "[::1]:443", "db.example.com:5432"
+	NetworkMAC                         // "00:1a:2b:3c:4d:5e"
+)
+
+var networkKindNames = map[NetworkKind]string{
+	NetworkAddr:     "address",
+	NetworkPrefix:   "prefix",
+	NetworkHostPort: "host:port",
+	NetworkMAC:      "mac",
+}
+
+func (k NetworkKind) String() string {
+	if name, prs := networkKindNames[k]; prs {
+		return name
+	}
+	return fmt.Sprintf("NetworkKind(%d)", int(k))
+}
+
+// hostnameRegex matches DNS host names, such as "localhost" and
+// "db-1.example.com".
+var hostnameRegex = regexp.MustCompile("^(?i:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?)(?:\\.(?i:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?))*$")
+
+// A Network is an IP address, IP prefix, host and port pair, or MAC
+// address.  Only the fields of its Kind are set.
+type Network struct {
+	Kind NetworkKind
+	// Addr is the address, or the host of a host:port pair when the
+	// host is an address.
+	Addr   netip.Addr
+	Prefix netip.Prefix
+	// Host and Port are the parts of a host:port pair.  Host is a host
+	// name, such as "localhost", or an address.
+	Host string
+	Port uint16
+	MAC  net.HardwareAddr
+}
+
+// A NetworkParser determines whether a string is a network value.  Each
+// kind of value may be enabled separately.
+type NetworkParser struct {
+	Addresses bool
+	Prefixes  bool
+	HostPorts bool
+	MACs      bool
+}
+
+// NewNetworkParser returns a NetworkParser that detects every kind of
+// network value.
+func NewNetworkParser() *NetworkParser {
+	return &NetworkParser{
+		Addresses: true,
+		Prefixes:  true,
+		HostPorts: true,
+		MACs:      true,
+	}
+}
+
+// Parse a string to determine if it is a network value.  The returned
+// value is a *Network instance.
+func (p NetworkParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParseNetwork is the same as Parse but returns a *Network instance.
+func (p NetworkParser) ParseNetwork(s string) (*Network, error) {
+	return p.parse(s)
+}
+
+func (p NetworkParser) parse(s string) (*Network, error) {
+	s = strings.TrimSpace(s)
+
+	if p.Addresses {
+		if addr, err := netip.ParseAddr(s); err == nil {
+			return &Network{Kind: NetworkAddr, Addr: addr}, nil
+		}
+	}
+	if p.Prefixes {
+		if prefix, err := netip.ParsePrefix(s); err == nil {
+			return &Network{Kind: NetworkPrefix, Prefix: prefix}, nil
+		}
+	}
+	if p.HostPorts {
+		if n, ok := parseHostPort(s); ok {
+			return n, nil
+		}
+	}
+	if p.MACs {
+		if mac, err := net.ParseMAC(s); err == nil {
+			return &Network{Kind: NetworkMAC, MAC: mac}, nil
+		}
+	}
+	return nil, errors.New(ParseNetworkError)
+}
+
+// parseHostPort parses an address or host name followed by a port.  A
+// host name must be "localhost" or have at least two labels and a
+// letter, so that times such as "12:30" and labels such as "Jan:2021"
+// and "Chapter:3" are not read as host:port pairs.
+func parseHostPort(s string) (*Network, bool) {
+	if ap, err := netip.ParseAddrPort(s); err == nil {
+		return &Network{
+			Kind: NetworkHostPort,
+			Addr: ap.Addr(),
+			Host: ap.Addr().String(),
+			Port: ap.Port(),
+		}, true
+	}
+
+	host, port, err := net.SplitHostPort(s)
+	if err != nil || !hostnameRegex.MatchString(host) ||
+		strings.IndexFunc(host, unicode.IsLetter) < 0 ||
+		!(strings.Contains(host, ".") || strings.EqualFold(host, "localhost")) ||
+		port == "" || strings.IndexFunc(port, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return nil, false
+	}
+	n, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, false
+	}
+	return &Network{Kind: NetworkHostPort, Host: host, Port: uint16(n)}, true
+}
@@ -0,0 +1,104 @@
+package multiparse
+
+import (
+	"net/netip"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkParserParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		kind NetworkKind
+		out  string
+	}{
+		{"10.0.0.1", NetworkAddr, "10.0.0.1"},
+		{" 192.168.1.254 ", NetworkAddr, "192.168.1.254"},
+		{"::1", NetworkAddr, "::1"},
+		{"2001:db8::8a2e:370:7334", NetworkAddr, "2001:db8::8a2e:370:7334"},
+		{"fe80::1%eth0", NetworkAddr, "fe80::1%eth0"},
+		{"10.0.0.0/8", NetworkPrefix, "10.0.0.0/8"},
+		{"10.1.2.3/8", NetworkPrefix, "10.1.2.3/8"},
+		{"2001:db8::/32", NetworkPrefix, "2001:db8::/32"},
+		{"10.0.0.1:80", NetworkHostPort, "10.0.0.1:80"},
+		{"[::1]:443", NetworkHostPort, "::1:443"},
+		{"localhost:8080", NetworkHostPort, "localhost:8080"},
+		{"db-1.example.com:5432", NetworkHostPort, "db-1.example.com:5432"},
+		{"00:1a:2b:3c:4d:5e", NetworkMAC, "00:1a:2b:3c:4d:5e"},
+		{"00-1A-2B-3C-4D-5E", NetworkMAC, "00:1a:2b:3c:4d:5e"},
+		{"001a.2b3c.4d5e", NetworkMAC, "00:1a:2b:3c:4d:5e"},
+	}
+
+	p := NewNetworkParser()
+	for _, tt := range tests {
+		n, err := p.ParseNetwork(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tt.kind, n.Kind, tt.in)
+		var out string
+		switch n.Kind {
+		case NetworkAddr:
+			out = n.Addr.String()
+		case NetworkPrefix:
+			out = n.Prefix.String()
+		case NetworkHostPort:
+			out = n.Host + ":" + strconv.Itoa(int(n.Port))
+		case NetworkMAC:
+			out = n.MAC.String()
+		}
+		assert.Equal(t, tt.out, out, tt.in)
+	}
+
+	fails := []string{
+		"", "1.2", "1.2.3", "10.0.0.256", "10.0.0.0/33", "12:30",
+		"12:30:45", "localhost", "localhost:", "localhost:http",
+		"localhost:65536", "-bad-:80", "00:1a:2b:3c:4d", "2019-12-05",
+		"hello world", "Jan:2021", "Chapter:3", "db:5432", "1.2:80",
+	}
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.EqualError(t, err, ParseNetworkError, tt)
+	}
+}
+
+func TestNetworkParserSelection(t *testing.T) {
+	p := &NetworkParser{Prefixes: true}
+	_, err := p.Parse("10.0.0.1")
+	assert.Error(t, err)
+	n, err := p.ParseNetwork("10.0.0.0/8")
+	assert.NoError(t, err)
+	assert.Equal(t, NetworkPrefix, n.Kind)
+
+	p = &NetworkParser{HostPorts: true}
+	n, err = p.ParseNetwork("10.0.0.1:80")
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("10.0.0.1"), n.Addr)
+	assert.Equal(t, uint16(80), n.Port)
+}
+
+func TestParserRegisterNetworkParser(t *testing.T) {
+	p := NewParser()
+	p.Register(NewNetworkParser())
+
+	parsed, err := p.ParseType("10.0.0.1")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsNetwork())
+	assert.False(t, parsed.IsNumeric())
+	assert.Equal(t, KindNetwork, parsed.Kind())
+	assert.Equal(t, NetworkAddr, parsed.Network().Kind)
+
+	parsed, err = p.ParseType("1.2")
+	assert.NoError(t, err)
+	assert.False(t, parsed.IsNetwork())
+	assert.Equal(t, KindFloat, parsed.Kind())
+
+	c := NewColumn("ip", p)
+	for _, s := range []string{"10.0.0.1", "::1", "192.168.0.1"} {
+		c.Add(s)
+	}
+	assert.Equal(t, "network, 100% coverage", c.Type().String())
+}
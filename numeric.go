@@ -2,6 +2,7 @@ package multiparse
 
 import (
 	"errors"
+	"math/big"
 	"regexp"
 	"strconv"
 	"strings"
@@ -10,10 +11,32 @@ import (
 // Numeric instances are containers for the various valid numerical types
 // that a string may be parsed into.
 type Numeric struct {
-	isInt   bool
-	isFloat bool
-	isMoney bool
-	f       float64
+	isInt     bool
+	isFloat   bool
+	isMoney   bool
+	ambiguous bool
+	ordinal   bool
+	f         float64
+	text      string // the original string
+	sanitized string // plain decimal text, e.g. "-1234.5" for "-$1,234.50"
+	code      bool
+	rat       *big.Rat // exact value of a fraction
+	format    numberFormat
+}
+
+// numberFormat records how a numeric string was written, so that other
+// values can be rendered in the same style.  Separators and decimal
+// places are only recorded when the string actually uses them.
+type numberFormat struct {
+	currency   string // currency symbol as written, e.g. "USD "
+	sign       string // explicit sign, "+" or "-"
+	digitSep   string
+	decimalSep string
+	decimals   int    // number of digits after the decimal separator
+	indian     bool   // digits grouped as 12,34,567
+	suffix     string // magnitude suffix as written, e.g. "M" or " lakh"
+	magnitude  int64  // value of the suffix
+	roman      string // "I" or "i" if written as a Roman numeral
 }
 
 // A NumericParser ingests a string and determines whether it is
@@ -24,6 +47,22 @@ type NumericParser struct {
 	CurrencySymbol   string
 	DigitSeparator   string
 	DecimalSeparator string
+	// RejectAmbiguous makes the parser return an error, rather than guess,
+	// when it cannot tell whether a separator groups digits or marks the
+	// decimal point, as in "123.456".
+	RejectAmbiguous bool
+	// Fractions makes the parser also accept vulgar fractions and mixed
+	// numbers, such as "3/4", "1 1/2", "½" and "2⅜".
+	Fractions bool
+	// Suffixes maps magnitude suffixes, such as the "M" in "$1.2M", to
+	// their values.  When set, the parser accepts numbers followed by
+	// one of the suffixes, possibly after a space.  See the
+	// MagnitudeSuffixes and IndianMagnitudeSuffixes tables.
+	Suffixes map[string]int64
+	// MaxDigits is the number of digits beyond which a string of digits
+	// is flagged as a code rather than a number.  Zero means
+	// DefaultMaxDigits.
+	MaxDigits int
 	// Unexported fields.
 	digitReStr    string
 	decimalReStr  string
@@ -33,6 +72,11 @@ type NumericParser struct {
 	currencyRegex *regexp.Regexp
 }
 
+// DefaultMaxDigits is the most digits a string of digits may have before
+// it is flagged as a code, such as a card or account number.  Integers
+// of up to 15 digits are represented exactly by a float64.
+const DefaultMaxDigits = 15
+
 // NewNumericParser with the default dictionary
 // currency symbol -> "", digit separator -> ",", decimal separator -> ".".
 func NewNumericParser() *NumericParser {
@@ -170,84 +214,31 @@ func (p NumericParser) sanitize(s string) (string, error) {
 	return s, err
 }
 
-// Parse a string representation of a money value which has one "." or ",".
-// Any string passed in should not begin or end with a delimiter.
-func (p NumericParser) parseOneUnknownSeparator(m string, i int) (string, error) {
-	// Split the string at the delimiter.
-	before := m[:i]
-	after := m[i+1:]
-
-	// Initially, assume the after var contins the non- integral part of m.
-	decimalSep := "."
-	if len(before) <= 3 && len(after) == 3 {
-		// Then m looks like "1,238" or "123.456" and we assume m is integral.
-		decimalSep = ""
-	}
-
-	fs := strings.Join([]string{before, after}, decimalSep)
-	return fs, nil
-}
-
-// Parse a string representation of a money value which has many "." or ",".
-func (p NumericParser) parseManyUnknownSeparators(m string, locs [][]int) (string, error) {
-	i := locs[len(locs)-1][0]
-	j := locs[len(locs)-2][0]
-	ci := string(m[i]) // last delimiter
-	cj := string(m[j]) // second to last delimiter
-
-	// Split the string at the last delimiter we encounter.
-	before := m[:i]
-	after := m[i+1:]
-
-	decimalSep := "."
-	if ci == cj {
-		// If the last two delimiters are equal, check whether all delimiters
-		// are equal.  If not, error, otherwise, we assume the string
-		// represents an integer.
-		for i, loc := range locs {
-			if i == len(locs)-1 {
-				break
-			}
-			if m[loc[0]] != m[locs[i+1][0]] {
-				return "", errors.New(ParseMonetaryStringError)
-			}
-		}
-		decimalSep = ""
-	}
-
-	// Remove all non-decimal indicator delimiters.
-	re := regexp.MustCompile(p.digitReStr)
-	before = re.ReplaceAllString(before, "")
-
-	fs := strings.Join([]string{before, after}, decimalSep)
-	return fs, nil
-}
-
-func (p NumericParser) parse(s string) (*Numeric, error) {
+// normalize strips the currency symbol and sign from s and checks that
+// what remains begins with a digit or decimal separator.  The returned
+// body has a leading 0 added and a trailing decimal separator removed,
+// so that ".5" becomes "0.5" and "12." becomes "12".  The currency
+// symbol and sign are recorded in the returned format.
+func (p NumericParser) normalize(s string) (string, numberFormat, bool, error) {
 	var (
-		n        *Numeric
-		err      error
-		sign     string
-		reStr    string
-		re       *regexp.Regexp
-		parseErr = errors.New(ParseNumericError)
+		format numberFormat
+		reStr  string
+		re     *regexp.Regexp
 	)
 
 	// Record whether the input string has a currency symbol.
 	// If so, it can only be a monetary value.
 	hasCurrency := p.currencyRegex.MatchString(s)
 	if hasCurrency {
+		format.currency = p.currencyRegex.FindString(s)
 		s = p.removeCurrencySymbol(s)
 	}
 
 	// Now determine whether the string's initial character is a + or -.
 	// If so, strip it away and record the sign.
-	sign = ""
 	re = regexp.MustCompile("^[\\+-]")
 	if re.MatchString(s) {
-		if re.FindString(s) == "-" {
-			sign = "-"
-		}
+		format.sign = re.FindString(s)
 		s = s[1:]
 	}
 
@@ -257,7 +248,7 @@ func (p NumericParser) parse(s string) (*Numeric, error) {
 	reStr = "^" + p.decimalReStr + "?" + "[0-9]"
 	re = regexp.MustCompile(reStr)
 	if !re.MatchString(s) {
-		return nil, parseErr
+		return "", format, false, errors.New(ParseNumericError)
 	}
 
 	// Prepend a 0 if the string begins with a decimal separator.
@@ -273,48 +264,131 @@ func (p NumericParser) parse(s string) (*Numeric, error) {
 		s = re.ReplaceAllString(s, "")
 	}
 
-	// Create the main validating regex.
-	reStr = "^\\d+" + "(" + p.digitReStr + "\\d{3})*" + p.decimalReStr + "?\\d*$"
-	re = regexp.MustCompile(reStr)
-	if !re.MatchString(s) {
-		return nil, parseErr
+	return s, format, hasCurrency, nil
+}
+
+func (p NumericParser) parse(s string) (*Numeric, error) {
+	n, _, err := p.parseText(s)
+	return n, err
+}
+
+// parseText parses s and also returns the exact text of the number, a
+// plain decimal such as "-1234.5" for "-$1,234.50" or a fraction such as
+// "3/2" for "1 1/2", for callers that need more precision than a float64
+// provides.  The text is always accepted by big.Rat's SetString method.
+// The original and sanitized text are retained on the Numeric, and
+// digit strings with leading zeros or more than MaxDigits digits are
+// flagged as codes.
+func (p NumericParser) parseText(s string) (*Numeric, string, error) {
+	n, text, err := p.parseNumber(s)
+	if err != nil {
+		return nil, "", err
+	}
+	maxDigits := p.MaxDigits
+	if maxDigits == 0 {
+		maxDigits = DefaultMaxDigits
 	}
+	n.text = s
+	n.sanitized = text
+	n.code = n.IsDigitString() && (n.HasLeadingZeros() || len(s) > maxDigits)
+	return n, text, nil
+}
 
-	// We can now assume that the string is valid except for
-	// intermediate delimiters.
-	// Before attempting to parse the string further, we (possibly) perform
-	// some basic sanitization.
-	var parsed string
-	tmp, err := p.sanitize(s)
-	if err == nil {
-		parsed = tmp
+// parseNumber parses s as a fraction, a number with a magnitude suffix,
+// or a decimal number.
+func (p NumericParser) parseNumber(s string) (*Numeric, string, error) {
+	if p.Fractions {
+		if n, text, err := p.parseFraction(s); err == nil {
+			return n, text, nil
+		}
+	}
+	if p.Suffixes != nil {
+		if n, text, err := p.parseSuffixed(s); err == nil {
+			return n, text, nil
+		}
+	}
+
+	var (
+		n         *Numeric
+		parsed    string
+		ambiguous bool
+		parseErr  = errors.New(ParseNumericError)
+	)
+
+	s, format, hasCurrency, err := p.normalize(s)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if p.digitReStr != p.decimalReStr {
+		// Create the main validating regex.  The integer part may be
+		// grouped as 1,234,567 or as 12,34,567.
+		reStr := "^(?:\\d+(?:" + p.digitReStr + "\\d{3})*" +
+			"|\\d{1,2}(?:" + p.digitReStr + "\\d{2})+" + p.digitReStr + "\\d{3})" +
+			"(?:" + p.decimalReStr + "\\d*)?$"
+		re := regexp.MustCompile(reStr)
+		if !re.MatchString(s) {
+			return nil, "", parseErr
+		}
+		parsed, err = p.sanitize(s)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Record the separators the string actually uses.
+		format.digitSep = p.digitRegex.FindString(s)
+		cleaned := p.digitRegex.ReplaceAllString(s, "")
+		if locs := p.decimalRegex.FindAllStringIndex(cleaned, -1); len(locs) > 0 {
+			loc := locs[len(locs)-1]
+			format.decimalSep = cleaned[loc[0]:loc[1]]
+			format.decimals = len(cleaned) - loc[1]
+		}
 	} else {
-		// Probably the parser cannot distinguish between decimal and digit
-		// separators.  So we handle this case separately.
-		re = regexp.MustCompile(p.digitReStr + "|" + p.decimalReStr)
-		locs := re.FindAllStringSubmatchIndex(s, -1)
-		switch len(locs) {
-		case 0: // The number is an integer.  No additional parsing needed.
-			parsed = s
-			err = nil
-		case 1: // Need to deal with 1,234 vs 123,456 vs 12.345, etc.
-			parsed, err = p.parseOneUnknownSeparator(s, locs[0][0])
-		default: // Try to find the last separator and determine its type.
-			parsed, err = p.parseManyUnknownSeparators(s, locs)
+		// The parser cannot distinguish between decimal and digit
+		// separators, so infer them from the shape of the string.
+		inf, err := inferSeparators(s, p.digitRegex)
+		if err != nil {
+			return nil, "", err
 		}
+		if inf.ambiguous && p.RejectAmbiguous {
+			return nil, "", errors.New(ParseAmbiguousSeparatorError)
+		}
+		ambiguous = inf.ambiguous
+		parsed = s
+		if inf.digit != "" {
+			parsed = strings.Replace(parsed, inf.digit, "", -1)
+		}
+		if inf.decimal != "" {
+			parsed = strings.Replace(parsed, inf.decimal, ".", 1)
+			format.decimals = len(parsed) - strings.Index(parsed, ".") - 1
+		}
+		format.digitSep = inf.digit
+		format.decimalSep = inf.decimal
+	}
 
+	if format.digitSep != "" {
+		intPart := s
+		if i := strings.LastIndex(s, format.decimalSep); format.decimalSep != "" && i >= 0 {
+			intPart = s[:i]
+		}
+		groups := strings.Split(intPart, format.digitSep)
+		format.indian = indianGrouping(groups) && !westernGrouping(groups)
 	}
 
-	parsed = sign + parsed
-	f, ferr := strconv.ParseFloat(parsed, 64)
-	if err != nil || ferr != nil {
-		return nil, err
+	if format.sign == "-" {
+		parsed = "-" + parsed
+	}
+	f, err := strconv.ParseFloat(parsed, 64)
+	if err != nil {
+		return nil, "", parseErr
 	}
 
 	// We now know that the parsed string correctly parses as a float.
 	n = &Numeric{
-		isFloat: true,
-		f:       f,
+		isFloat:   true,
+		ambiguous: ambiguous,
+		f:         f,
+		format:    format,
 	}
 	if hasCurrency {
 		n.isMoney = true
@@ -324,7 +398,7 @@ func (p NumericParser) parse(s string) (*Numeric, error) {
 		n.isInt = true
 	}
 
-	return n, nil
+	return n, parsed, nil
 }
 
 // Int reports whether the Numeric instance can be an integer
@@ -354,3 +428,59 @@ func (x Numeric) IsFloat() bool {
 func (x Numeric) IsMoney() bool {
 	return x.isMoney
 }
+
+// IsAmbiguous reports whether the parser had to guess the role of a
+// separator, as in "1,234", which may be either 1234 or 1.234.
+func (x Numeric) IsAmbiguous() bool {
+	return x.ambiguous
+}
+
+// Text returns the original string.
+func (x Numeric) Text() string {
+	return x.text
+}
+
+// Sanitized returns the number as plain text, without currency symbols
+// or digit separators and with a "." decimal point, e.g. "-1234.5" for
+// "-$1,234.50".  Leading zeros are kept, so "00123" is "00123".
+func (x Numeric) Sanitized() string {
+	return x.sanitized
+}
+
+// HasLeadingZeros reports if the integer part of the number has leading
+// zeros, as in "00123" and "-007".
+func (x Numeric) HasLeadingZeros() bool {
+	s := strings.TrimLeft(x.sanitized, "+-")
+	return len(s) > 1 && s[0] == '0' && s[1] >= '0' && s[1] <= '9'
+}
+
+// IsDigitString reports if the original string consists only of the
+// digits 0 to 9, as ZIP codes, SKUs and account numbers often do.
+func (x Numeric) IsDigitString() bool {
+	if x.text == "" {
+		return false
+	}
+	for i := 0; i < len(x.text); i++ {
+		if x.text[i] < '0' || x.text[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Width returns the number of digits of a digit string, or 0 if the
+// original string is not one.
+func (x Numeric) Width() int {
+	if !x.IsDigitString() {
+		return 0
+	}
+	return len(x.text)
+}
+
+// IsCode reports if the original string is a digit string that is
+// better treated as text than as a number, since it has leading zeros,
+// like the ZIP code "02134", or more digits than the parser's
+// MaxDigits, like a 16 digit account number.
+func (x Numeric) IsCode() bool {
+	return x.code
+}
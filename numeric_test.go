@@ -1,6 +1,7 @@
 package multiparse
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -16,60 +17,77 @@ func TestNumericParserParse(t *testing.T) {
 		{
 			"123",
 			&Numeric{
-				isInt:   true,
-				isFloat: true,
-				isMoney: false,
-				f:       123.0,
+				isInt:     true,
+				isFloat:   true,
+				isMoney:   false,
+				f:         123.0,
+				text:      "123",
+				sanitized: "123",
 			},
 		},
 		// Int
 		{
 			"123,456",
 			&Numeric{
-				isInt:   true,
-				isFloat: true,
-				isMoney: false,
-				f:       123456,
+				isInt:     true,
+				isFloat:   true,
+				isMoney:   false,
+				f:         123456,
+				text:      "123,456",
+				sanitized: "123456",
+				format:    numberFormat{digitSep: ","},
 			},
 		},
 		// Float
 		{
 			"123.4",
 			&Numeric{
-				isInt:   false,
-				isFloat: true,
-				isMoney: false,
-				f:       123.4,
+				isInt:     false,
+				isFloat:   true,
+				isMoney:   false,
+				f:         123.4,
+				text:      "123.4",
+				sanitized: "123.4",
+				format:    numberFormat{decimalSep: ".", decimals: 1},
 			},
 		},
 		// Float
 		{
 			"12,345.67",
 			&Numeric{
-				isInt:   false,
-				isFloat: true,
-				isMoney: false,
-				f:       12345.67,
+				isInt:     false,
+				isFloat:   true,
+				isMoney:   false,
+				f:         12345.67,
+				text:      "12,345.67",
+				sanitized: "12345.67",
+				format:    numberFormat{digitSep: ",", decimalSep: ".", decimals: 2},
 			},
 		},
 		// Only money
 		{
 			"$123.45",
 			&Numeric{
-				isInt:   false,
-				isFloat: true,
-				isMoney: true,
-				f:       123.45,
+				isInt:     false,
+				isFloat:   true,
+				isMoney:   true,
+				f:         123.45,
+				text:      "$123.45",
+				sanitized: "123.45",
+				format:    numberFormat{currency: "$", decimalSep: ".", decimals: 2},
 			},
 		},
 		// Another money
 		{
 			"€123.45",
 			&Numeric{
-				isInt:   false,
-				isFloat: true,
-				isMoney: true,
-				f:       123.45,
+				isInt:     false,
+				isFloat:   true,
+				isMoney:   true,
+				f:         123.45,
+				text:      "€123.45",
+				sanitized: "123.45",
+				format:    numberFormat{currency: "€", decimalSep: ".", decimals: 2},
 			},
 		},
 		// Fail case
@@ -99,10 +117,13 @@ func TestNumericParserParse(t *testing.T) {
 func TestCustomNumericParserParse(t *testing.T) {
 	in := "€123,45"
 	expected := &Numeric{
-		isInt:   false,
-		isFloat: true,
-		isMoney: true,
-		f:       123.45,
+		isInt:     false,
+		isFloat:   true,
+		isMoney:   true,
+		f:         123.45,
+		text:      "€123,45",
+		sanitized: "123.45",
+		format:    numberFormat{currency: "€", decimalSep: ",", decimals: 2},
 	}
 	p := NewCustomNumericParser("", "", "")
 	actual, err := p.parse(in)
@@ -120,40 +141,51 @@ func TestUSDNumericParserParse(t *testing.T) {
 		{
 			"123",
 			&Numeric{
-				isInt:   true,
-				isFloat: true,
-				isMoney: false,
-				f:       123.0,
+				isInt:     true,
+				isFloat:   true,
+				isMoney:   false,
+				f:         123.0,
+				text:      "123",
+				sanitized: "123",
 			},
 		},
 		// Float
 		{
 			"123.4",
 			&Numeric{
-				isInt:   false,
-				isFloat: true,
-				isMoney: false,
-				f:       123.4,
+				isInt:     false,
+				isFloat:   true,
+				isMoney:   false,
+				f:         123.4,
+				text:      "123.4",
+				sanitized: "123.4",
+				format:    numberFormat{decimalSep: ".", decimals: 1},
 			},
 		},
 		// Only money
 		{
 			"$123.45",
 			&Numeric{
-				isInt:   false,
-				isFloat: true,
-				isMoney: true,
-				f:       123.45,
+				isInt:     false,
+				isFloat:   true,
+				isMoney:   true,
+				f:         123.45,
+				text:      "$123.45",
+				sanitized: "123.45",
+				format:    numberFormat{currency: "$", decimalSep: ".", decimals: 2},
 			},
 		},
 		// Another money
 		{
 			"$123,456",
 			&Numeric{
-				isInt:   true,
-				isFloat: true,
-				isMoney: true,
-				f:       123456.0,
+				isInt:     true,
+				isFloat:   true,
+				isMoney:   true,
+				f:         123456.0,
+				text:      "$123,456",
+				sanitized: "123456",
+				format:    numberFormat{currency: "$", digitSep: ","},
 			},
 		},
 		// Fail case
@@ -241,3 +273,94 @@ func TestParseNumeric(t *testing.T) {
 		}
 	}
 }
+
+func FuzzNumericParserParse(f *testing.F) {
+	seeds := []string{
+		"123", "-123,456.78", "+1.234,5", "$1,23,456", "€123,45", ".5",
+		"12.", "USD 12", "1.234.5", "0,123", "0100,000", "1e5", "", "$", "--1",
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	parsers := []*NumericParser{
+		NewNumericParser(),
+		NewUSDNumericParser(),
+		NewCustomNumericParser("", "", ""),
+		NewCustomNumericParser("", ".", ","),
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, p := range parsers {
+			n, err := p.parse(s)
+			if err != nil {
+				if n != nil {
+					t.Fatalf("%q: non-nil result with error %v", s, err)
+				}
+				continue
+			}
+			if !n.IsFloat() {
+				t.Fatalf("%q: numeric value is not a float", s)
+			}
+			if n.IsInt() && n.Float() != math.Trunc(n.Float()) {
+				t.Fatalf("%q: integer has fractional part %v", s, n.Float())
+			}
+
+			// Formatting the value in the source style parses to the same
+			// value, unless the parser has to guess, as when "0100,000"
+			// is formatted as "100,000".
+			parsed := NewParsed()
+			parsed.Numeric = n
+			formatted := NewFormatter(parsed).FormatFloat(n.Float())
+			m, err := p.parse(formatted)
+			if err != nil {
+				t.Fatalf("%q formatted as %q: %v", s, formatted, err)
+			}
+			if m.IsAmbiguous() && !n.IsAmbiguous() {
+				continue
+			}
+			if m.Float() != n.Float() || m.IsMoney() != n.IsMoney() {
+				t.Fatalf("%q formatted as %q parses to %v, not %v", s, formatted, m.Float(), n.Float())
+			}
+		}
+	})
+}
+
+func TestNumericText(t *testing.T) {
+	tests := []struct {
+		in           string
+		sanitized    string
+		leadingZeros bool
+		digits       bool
+		width        int
+		code         bool
+	}{
+		{"00123", "00123", true, true, 5, true},
+		{"02134", "02134", true, true, 5, true},
+		{"-007", "-007", true, false, 0, false},
+		{"0", "0", false, true, 1, false},
+		{"0.5", "0.5", false, false, 0, false},
+		{".5", "0.5", false, false, 0, false},
+		{"12345", "12345", false, true, 5, false},
+		{"$1,234.50", "1234.50", false, false, 0, false},
+		{"123456789012345", "123456789012345", false, true, 15, false},
+		{"1234567890123456", "1234567890123456", false, true, 16, true},
+	}
+
+	p := NewNumericParser()
+	for _, tt := range tests {
+		n, err := p.ParseNumeric(tt.in)
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.in, n.Text(), tt.in)
+		assert.Equal(t, tt.sanitized, n.Sanitized(), tt.in)
+		assert.Equal(t, tt.leadingZeros, n.HasLeadingZeros(), tt.in)
+		assert.Equal(t, tt.digits, n.IsDigitString(), tt.in)
+		assert.Equal(t, tt.width, n.Width(), tt.in)
+		assert.Equal(t, tt.code, n.IsCode(), tt.in)
+	}
+
+	p.MaxDigits = 4
+	n, err := p.ParseNumeric("12345")
+	assert.NoError(t, err)
+	assert.True(t, n.IsCode())
+	assert.Equal(t, 12345, n.Int())
+}
@@ -1,15 +1,31 @@
 package multiparse
 
-import "time"
+import (
+	"net/url"
+	"time"
+)
 
 // Parsed is the most general type description of a string.
 type Parsed struct {
 	*Numeric
+	text      string // the original string
 	isNumeric bool
 	isTime    bool
 	isBool    bool
 	time      time.Time
+	layout    string
+	precision Precision
+	partial   *PartialDate
 	b         bool
+	quantity  *Quantity
+	network   *Network
+	id        *Identifier
+	phone     *Phone
+	coord     *Coordinate
+	rng       *Range
+	list      *List
+	email     *Email
+	url       *url.URL
 }
 
 // NewParsed returns a Parsed instance with zero values.
@@ -19,6 +35,47 @@ func NewParsed() *Parsed {
 	}
 }
 
+// Kind returns the most specific kind of value the parsed string
+// represents.  Strings that are of several kinds, such as "1", are
+// reported as identifier, phone, coordinate, range, list, code, money,
+// int, float, time, quantity, network, email, URL or bool, in that order
+// of preference.
+func (p Parsed) Kind() Kind {
+	switch {
+	case p.id != nil:
+		return KindIdentifier
+	case p.phone != nil:
+		return KindPhone
+	case p.coord != nil:
+		return KindCoordinate
+	case p.rng != nil:
+		return KindRange
+	case p.list != nil:
+		return KindList
+	case p.isNumeric && p.IsCode():
+		return KindCode
+	case p.isNumeric && p.IsMoney():
+		return KindMoney
+	case p.isNumeric && p.IsInt():
+		return KindInt
+	case p.isNumeric:
+		return KindFloat
+	case p.isTime:
+		return KindTime
+	case p.quantity != nil:
+		return KindQuantity
+	case p.network != nil:
+		return KindNetwork
+	case p.email != nil:
+		return KindEmail
+	case p.url != nil:
+		return KindURL
+	case p.isBool:
+		return KindBool
+	}
+	return KindString
+}
+
 // IsTime reports if the parsed string represents a datetime.
 func (p Parsed) IsTime() bool {
 	return p.isTime
@@ -44,11 +101,151 @@ func (p Parsed) Time() time.Time {
 	return p.time
 }
 
+// TimeLayout returns the layout of the parsed datetime string, or
+// the empty string if it is not a datetime or the layout is unknown.
+func (p Parsed) TimeLayout() string {
+	return p.layout
+}
+
+// TimePrecision returns the precision of the parsed datetime string,
+// such as PrecisionMonth for "2021-02", or PrecisionUnknown if it is not
+// a datetime or the precision is unknown.
+func (p Parsed) TimePrecision() Precision {
+	return p.precision
+}
+
+// IsPartialDate reports if the parsed string names a period without
+// giving a complete date, such as "2021-03", "Q3 2021" or "FY22".
+func (p Parsed) IsPartialDate() bool {
+	return p.partial != nil
+}
+
+// PartialDate instance of the string if it parses as such, or nil if it
+// does not.
+func (p Parsed) PartialDate() *PartialDate {
+	return p.partial
+}
+
 // Bool instance of the string if it parses as such, or
 // the default value if it does not.
 func (p Parsed) Bool() bool {
 	if !p.isBool {
 		return false
 	}
-	return true
+	return p.b
+}
+
+// IsQuantity reports if the parsed string represents a physical quantity.
+// Quantities are only detected by parsers with a registered
+// QuantityParser.
+func (p Parsed) IsQuantity() bool {
+	return p.quantity != nil
+}
+
+// Quantity instance of the string if it parses as such, or nil if it
+// does not.
+func (p Parsed) Quantity() *Quantity {
+	return p.quantity
+}
+
+// IsNetwork reports if the parsed string represents a network value,
+// such as an IP address.  Network values are only detected by parsers
+// with a registered NetworkParser.
+func (p Parsed) IsNetwork() bool {
+	return p.network != nil
+}
+
+// Network instance of the string if it parses as such, or nil if it
+// does not.
+func (p Parsed) Network() *Network {
+	return p.network
+}
+
+// IsIdentifier reports if the parsed string represents an identifier
+// with a valid checksum, such as an ISBN.  Identifiers are only
+// detected by parsers with a registered IdentifierParser.
+func (p Parsed) IsIdentifier() bool {
+	return p.id != nil
+}
+
+// Identifier instance of the string if it parses as such, or nil if it
+// does not.
+func (p Parsed) Identifier() *Identifier {
+	return p.id
+}
+
+// IsPhone reports if the parsed string represents a phone number.  Phone
+// numbers are only detected by parsers with a registered PhoneParser.
+func (p Parsed) IsPhone() bool {
+	return p.phone != nil
+}
+
+// Phone instance of the string if it parses as such, or nil if it does
+// not.
+func (p Parsed) Phone() *Phone {
+	return p.phone
+}
+
+// IsCoordinate reports if the parsed string represents a geographic
+// coordinate.  Coordinates are only detected by parsers with a
+// registered CoordinateParser.
+func (p Parsed) IsCoordinate() bool {
+	return p.coord != nil
+}
+
+// Coordinate instance of the string if it parses as such, or nil if it
+// does not.
+func (p Parsed) Coordinate() *Coordinate {
+	return p.coord
+}
+
+// IsRange reports if the parsed string represents a range of numbers or
+// times.  Ranges are only detected by parsers with a registered
+// RangeParser.
+func (p Parsed) IsRange() bool {
+	return p.rng != nil
+}
+
+// Range instance of the string if it parses as such, or nil if it does
+// not.
+func (p Parsed) Range() *Range {
+	return p.rng
+}
+
+// IsList reports if the parsed string represents a list of values of
+// the same kind.  Lists are only detected by parsers with a registered
+// ListParser.
+func (p Parsed) IsList() bool {
+	return p.list != nil
+}
+
+// List instance of the string if it parses as such, or nil if it does
+// not.
+func (p Parsed) List() *List {
+	return p.list
+}
+
+// IsEmail reports if the parsed string represents an email address.
+// Email addresses are only detected by parsers with a registered
+// EmailParser.
+func (p Parsed) IsEmail() bool {
+	return p.email != nil
+}
+
+// Email instance of the string if it parses as such, or nil if it does
+// not.
+func (p Parsed) Email() *Email {
+	return p.email
+}
+
+// IsURL reports if the parsed string represents an absolute URL.  URLs
+// are only detected by parsers with a registered URLParser.
+func (p Parsed) IsURL() bool {
+	return p.url != nil
+}
+
+// URL instance of the string if it parses as such, or nil if it does
+// not.
+func (p Parsed) URL() *url.URL {
+	return p.url
 }
@@ -29,6 +29,9 @@ func TestParsedTime(t *testing.T) {
 func TestParsedBool(t *testing.T) {
 	b, _ := ParseType("yes")
 	b2, _ := ParseType("123")
+	b3, _ := ParseType("no")
 	assert.True(t, b.Bool())
 	assert.False(t, b2.Bool())
+	assert.True(t, b3.IsBool())
+	assert.False(t, b3.Bool())
 }
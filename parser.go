@@ -2,6 +2,8 @@ package multiparse
 
 import (
 	"errors"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -14,6 +16,8 @@ type Parser struct {
 	numeric Interface
 	time    Interface
 	b       Interface
+	// Optional detectors added with Register.
+	detectors []Interface
 }
 
 // NewGeneralParser constructs a general purpose top-level Parser instance.
@@ -39,7 +43,9 @@ func NewUSDParser() *Parser {
 // NewParser is a general purpose parser that uses the passed in
 // Interface interfaces to determine whether a string is a numeric or
 // time representation.  The provided parsers should return *Numeric and
-// *Time instances, respectively.
+// either *Time or time.Time instances, respectively.  The layout and
+// precision of times are recorded when the time parser has a
+// ParseTimeLayout method, as the TimeParser does.
 func NewCustomParser(numeric, time, boolean Interface) *Parser {
 	return &Parser{
 		numeric: numeric,
@@ -48,6 +54,15 @@ func NewCustomParser(numeric, time, boolean Interface) *Parser {
 	}
 }
 
+// Register adds optional detectors to the parser, such as a
+// QuantityParser.  Each string is also parsed by every registered
+// detector, and the values they return are reported on the Parsed
+// instance.  Detectors must return one of the types the Parsed type
+// reports, such as *Quantity or *Numeric.
+func (p *Parser) Register(detectors ...Interface) {
+	p.detectors = append(p.detectors, detectors...)
+}
+
 // Parse a string to determine if it is a numeric or monetary value.
 // This method is defined primarily so that the Parser struct satifies
 // the Interface interface.
@@ -113,11 +128,10 @@ func (p Parser) ParseBool(s string) (bool, error) {
 // convert to the appropriate types or when the string does not
 // parse into either a numeric or time type.
 func (p Parser) parse(s string) (*Parsed, error) {
-	var numericAssertErr error
-	var timeAssertErr error
-	var boolAssertErr error
+	var assertErrs []string
 
 	parsed := NewParsed()
+	parsed.text = s
 
 	x, numericError := p.numeric.Parse(s)
 	if numericError == nil {
@@ -126,21 +140,47 @@ func (p Parser) parse(s string) (*Parsed, error) {
 			parsed.isNumeric = true
 			parsed.Numeric = t
 		default:
-			numericAssertErr = errors.New(ParseTypeAssertError)
+			assertErrs = append(assertErrs, ParseTypeAssertError)
 		}
 	}
 
-	ti, timeError := p.time.Parse(s)
+	var ti interface{}
+	var timeError error
+	if lp, ok := p.time.(layoutParser); ok {
+		ti, timeError = lp.ParseTimeLayout(s)
+	} else {
+		ti, timeError = p.time.Parse(s)
+	}
 	if timeError == nil {
 		switch t := ti.(type) {
+		case *Time:
+			parsed.isTime = true
+			parsed.time = t.Time
+			parsed.layout = t.layout
+			parsed.precision = t.precision
+			parsed.partial = t.PartialDate()
 		case time.Time:
 			parsed.isTime = true
 			parsed.time = t
 		default:
-			timeAssertErr = errors.New(ParseTypeAssertError)
+			assertErrs = append(assertErrs, ParseTypeAssertError)
 		}
 	}
 
+	// Dates such as "1/2/06" are never fractions, but a custom time
+	// layout may accept strings that are, e.g. "3/4" as March 4.  The
+	// layout is the more specific reading.
+	//
+	// Likewise, the NumericParser reads the "Mar" of "Mar 2021" and the
+	// "FY" of "FY22" as currency codes, but partial dates are never
+	// amounts of money.
+	if parsed.isTime && parsed.isNumeric &&
+		(parsed.Numeric.IsFraction() || parsed.partial != nil && parsed.Numeric.IsMoney()) {
+		parsed.isNumeric = false
+		parsed.Numeric = new(Numeric)
+		numericError = errors.New(ParseNumericError)
+	}
+
 	b, boolError := p.b.Parse(s)
 	if boolError == nil {
 		switch t := b.(type) {
@@ -148,18 +188,71 @@ func (p Parser) parse(s string) (*Parsed, error) {
 			parsed.isBool = true
 			parsed.b = t
 		default:
-			boolAssertErr = errors.New(ParseBoolError)
+			assertErrs = append(assertErrs, ParseTypeAssertError)
+		}
+	}
+
+	detected := false
+	for _, d := range p.detectors {
+		x, err := d.Parse(s)
+		if err != nil {
+			continue
 		}
+		detected = true
+		switch t := x.(type) {
+		case *Quantity:
+			parsed.quantity = t
+		case *Network:
+			parsed.network = t
+		case *Identifier:
+			parsed.id = t
+		case *Phone:
+			parsed.phone = t
+		case *Coordinate:
+			parsed.coord = t
+		case *Range:
+			parsed.rng = t
+		case *List:
+			parsed.list = t
+		case *Email:
+			parsed.email = t
+		case *url.URL:
+			parsed.url = t
+		case *Numeric:
+			// The NumericParser's reading takes precedence.
+			if !parsed.isNumeric {
+				parsed.isNumeric = true
+				parsed.Numeric = t
+			}
+		default:
+			assertErrs = append(assertErrs, ParseTypeAssertError)
+		}
+	}
+
+	// A pair of decimal degrees such as "37.7749,-122.4194" may also
+	// read as a number with grouped digits, but never is one.
+	if parsed.coord != nil && parsed.isNumeric {
+		parsed.isNumeric = false
+		parsed.Numeric = new(Numeric)
+	}
+
+	// The TimeParser reads an interval such as "2020-01-01/2020-03-31",
+	// or a list such as "2020-01-01, 2020-02-01", as its first date.
+	if (parsed.rng != nil || parsed.list != nil) && parsed.isTime {
+		parsed.isTime = false
+		parsed.time = time.Time{}
+		parsed.layout = ""
+		parsed.precision = PrecisionUnknown
+		parsed.partial = nil
 	}
 
-	if numericAssertErr != nil || timeAssertErr != nil || boolAssertErr != nil {
-		err := numericAssertErr.Error()
-		err += timeAssertErr.Error()
-		err += boolAssertErr.Error()
-		return nil, errors.New(err)
+	// Only some of the underlying parsers may have returned values of
+	// the wrong type, so report each failure that occurred.
+	if len(assertErrs) > 0 {
+		return nil, errors.New(strings.Join(assertErrs, " "))
 	}
 
-	if numericError != nil && timeError != nil && boolError != nil {
+	if numericError != nil && timeError != nil && boolError != nil && !detected {
 		return nil, errors.New(ParseError)
 	}
 
@@ -84,6 +84,18 @@ func TestBadParsers(t *testing.T) {
 	_, err := parser.Parse("123")
 	assert.Error(t, err)
 	_, err = parser.ParseType("123")
+	assert.Error(t, err)
+
+	// Only some of the parsers return values of the wrong type.
+	parsers := []*Parser{
+		NewCustomParser(b1, NewTimeParser(), NewBooleanParser()),
+		NewCustomParser(NewNumericParser(), b2, NewBooleanParser()),
+		NewCustomParser(NewNumericParser(), NewTimeParser(), b3),
+	}
+	for _, parser := range parsers {
+		_, err = parser.ParseType("123")
+		assert.EqualError(t, err, ParseTypeAssertError)
+	}
 }
 
 func TestParserParseType(t *testing.T) {
@@ -208,3 +220,32 @@ func ExampleParseType() {
 	fmt.Println(p.Float())
 	// output: 12345
 }
+
+func FuzzParserParse(f *testing.F) {
+	seeds := []string{"123", "$1,234.50", "2015-01-02", "yes", "abc", ""}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	parsers := []*Parser{NewParser(), NewUSDParser()}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, p := range parsers {
+			parsed, err := p.parse(s)
+			if err != nil {
+				if parsed != nil {
+					t.Fatalf("%q: non-nil result with error %v", s, err)
+				}
+				continue
+			}
+			if !parsed.IsNumeric() && !parsed.IsTime() && !parsed.IsBool() {
+				t.Fatalf("%q: parsed without a type", s)
+			}
+			if parsed.IsMoney() && !parsed.IsNumeric() {
+				t.Fatalf("%q: money is not numeric", s)
+			}
+			if parsed.IsInt() && !parsed.IsFloat() {
+				t.Fatalf("%q: int is not a float", s)
+			}
+		}
+	})
+}
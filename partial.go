@@ -0,0 +1,169 @@
+package multiparse
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// monthYearLayouts are the layouts of months such as "Mar 2021".
+var monthYearLayouts = []string{"Jan 2006", "Jan. 2006", "January 2006"}
+
+var (
+	yearRegex       = regexp.MustCompile("^\\d{4}$")
+	fiscalYearRegex = regexp.MustCompile("^FY(\\d{2}|\\d{4})$")
+	periodRegex     = regexp.MustCompile("^([QH])([1-4])$")
+)
+
+// A PartialDate is a period of time named by a string that does not
+// give a complete date, such as the month "Mar 2021", the quarter
+// "Q3 2021" or the fiscal year "FY22".  The period starts at Start and
+// ends just before End.
+type PartialDate struct {
+	Start     time.Time
+	End       time.Time
+	Precision Precision
+	// Fiscal reports whether the period is a fiscal year or part of one.
+	Fiscal bool
+}
+
+// Contains reports whether t is within the period.
+func (d PartialDate) Contains(t time.Time) bool {
+	return !t.Before(d.Start) && t.Before(d.End)
+}
+
+// PartialDate returns the period the time's string names when it is not
+// a complete date, as with "2021-03" and "Q3 2021", and nil otherwise.
+func (t Time) PartialDate() *PartialDate {
+	var end time.Time
+	switch t.precision {
+	case PrecisionYear:
+		end = t.AddDate(1, 0, 0)
+	case PrecisionHalf:
+		end = t.AddDate(0, 6, 0)
+	case PrecisionQuarter:
+		end = t.AddDate(0, 3, 0)
+	case PrecisionMonth:
+		end = t.AddDate(0, 1, 0)
+	case PrecisionWeek:
+		end = t.AddDate(0, 0, 7)
+	default:
+		return nil
+	}
+	return &PartialDate{
+		Start:     t.Time,
+		End:       end,
+		Precision: t.precision,
+		Fiscal:    t.fiscal,
+	}
+}
+
+// ParsePartialDate parses a string that names a period without giving
+// a complete date, such as "2021", "2021-03", "Mar 2021", "Q3 2021",
+// "FY22" or "H1 2020".
+func (p TimeParser) ParsePartialDate(s string) (*PartialDate, error) {
+	t, err := p.parse(s)
+	if err != nil {
+		return nil, err
+	}
+	d := t.PartialDate()
+	if d == nil {
+		return nil, errors.New(ParseTimeError)
+	}
+	return d, nil
+}
+
+// fiscalYear returns the start of the fiscal year that ends in the
+// given calendar year.  A fiscal year is named after the year it ends
+// in, so when fiscal years start in October, FY22 starts in October
+// 2021.
+func (p TimeParser) fiscalYear(year int) time.Time {
+	start := p.FiscalYearStart
+	if start < time.January || start > time.December {
+		start = time.January
+	}
+	if start != time.January {
+		year--
+	}
+	return time.Date(year, start, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// parsePeriod parses months such as "Mar 2021", years and fiscal years
+// such as "2021" and "FY22", and quarters and halves of them such as
+// "Q3 2021", "2021-Q3", "H1 FY2020" and "Q2 FY 22".
+func (p TimeParser) parsePeriod(s string) *Time {
+	if t := parseLayouts(monthYearLayouts, s); t != nil {
+		return t
+	}
+
+	s = strings.NewReplacer("-", " ", "/", " ").Replace(strings.ToUpper(s))
+	var fields []string
+	for _, f := range strings.Fields(s) {
+		// "FY 22" is "FY22".
+		if n := len(fields); n > 0 && fields[n-1] == "FY" {
+			fields[n-1] += f
+			continue
+		}
+		fields = append(fields, f)
+	}
+
+	var year, period string
+	switch len(fields) {
+	case 1:
+		year = fields[0]
+	case 2:
+		year, period = fields[1], fields[0]
+		if periodRegex.MatchString(fields[1]) {
+			year, period = fields[0], fields[1]
+		}
+	default:
+		return nil
+	}
+
+	t := &Time{precision: PrecisionYear}
+	switch m := fiscalYearRegex.FindStringSubmatch(year); {
+	case yearRegex.MatchString(year):
+		y, _ := strconv.Atoi(year)
+		if period == "" && (y < minDigitYear || y > maxDigitYear) {
+			return nil
+		}
+		t.Time = time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+	case m != nil:
+		y, _ := strconv.Atoi(m[1])
+		if len(m[1]) == 2 {
+			// Two digit years are read as Go reads them, so FY99 ends
+			// in 1999 and FY22 in 2022.
+			y += 2000
+			if y >= 2069 {
+				y -= 100
+			}
+		}
+		t.Time = p.fiscalYear(y)
+		t.fiscal = true
+	default:
+		return nil
+	}
+
+	if period == "" {
+		return t
+	}
+	m := periodRegex.FindStringSubmatch(period)
+	if m == nil {
+		return nil
+	}
+	n, _ := strconv.Atoi(m[2])
+	switch m[1] {
+	case "Q":
+		t.Time = t.AddDate(0, 3*(n-1), 0)
+		t.precision = PrecisionQuarter
+	case "H":
+		if n > 2 {
+			return nil
+		}
+		t.Time = t.AddDate(0, 6*(n-1), 0)
+		t.precision = PrecisionHalf
+	}
+	return t
+}
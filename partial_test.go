@@ -0,0 +1,127 @@
+package multiparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeParserParsePartialDate(t *testing.T) {
+	date := func(y int, m time.Month) time.Time {
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		in         string
+		start, end time.Time
+		precision  Precision
+		fiscal     bool
+	}{
+		{"2021", date(2021, 1), date(2022, 1), PrecisionYear, false},
+		{"2021-03", date(2021, 3), date(2021, 4), PrecisionMonth, false},
+		{"Mar 2021", date(2021, 3), date(2021, 4), PrecisionMonth, false},
+		{"March 2021", date(2021, 3), date(2021, 4), PrecisionMonth, false},
+		{"Q3 2021", date(2021, 7), date(2021, 10), PrecisionQuarter, false},
+		{"2021-Q3", date(2021, 7), date(2021, 10), PrecisionQuarter, false},
+		{"q4 2021", date(2021, 10), date(2022, 1), PrecisionQuarter, false},
+		{"H1 2020", date(2020, 1), date(2020, 7), PrecisionHalf, false},
+		{"2020 H2", date(2020, 7), date(2021, 1), PrecisionHalf, false},
+		{"FY22", date(2022, 1), date(2023, 1), PrecisionYear, true},
+		{"FY 2022", date(2022, 1), date(2023, 1), PrecisionYear, true},
+		{"FY99", date(1999, 1), date(2000, 1), PrecisionYear, true},
+		{"Q2 FY22", date(2022, 4), date(2022, 7), PrecisionQuarter, true},
+		{"2021-W05", time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 2, 8, 0, 0, 0, 0, time.UTC), PrecisionWeek, false},
+	}
+
+	p := NewTimeParser()
+	for _, tt := range tests {
+		d, err := p.ParsePartialDate(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tt.start, d.Start, tt.in)
+		assert.Equal(t, tt.end, d.End, tt.in)
+		assert.Equal(t, tt.precision, d.Precision, tt.in)
+		assert.Equal(t, tt.fiscal, d.Fiscal, tt.in)
+		assert.True(t, d.Contains(tt.start), tt.in)
+		assert.False(t, d.Contains(tt.end), tt.in)
+	}
+
+	fails := []string{
+		"2021-03-04", "2021-02-04T15:30", "Q5 2021", "H3 2021", "Q3",
+		"FY", "FY222", "Q3 H1 2021", "2021 2022", "abc", "", "1234", "0000",
+	}
+	for _, tt := range fails {
+		_, err := p.ParsePartialDate(tt)
+		assert.Error(t, err, tt)
+	}
+
+	_, err := NewCustomTimeParser(nil, nil).ParsePartialDate("Q3 2021")
+	assert.Error(t, err)
+}
+
+func TestTimeParserFiscalYearStart(t *testing.T) {
+	p := NewTimeParser()
+	p.FiscalYearStart = time.October
+
+	d, err := p.ParsePartialDate("FY22")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC), d.Start)
+	assert.Equal(t, time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), d.End)
+
+	d, err = p.ParsePartialDate("Q2 FY22")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), d.Start)
+	assert.Equal(t, PrecisionQuarter, d.Precision)
+
+	d, err = p.ParsePartialDate("H2 FY2022")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC), d.Start)
+
+	// Calendar quarters are unaffected.
+	d, err = p.ParsePartialDate("Q2 2022")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC), d.Start)
+	assert.False(t, d.Fiscal)
+}
+
+func TestParserPartialDate(t *testing.T) {
+	p := NewParser()
+	for _, s := range []string{"Mar 2021", "Q3 2021", "FY22", "H1 2020"} {
+		parsed, err := p.ParseType(s)
+		assert.NoError(t, err, s)
+		if err != nil {
+			continue
+		}
+		assert.True(t, parsed.IsPartialDate(), s)
+		assert.False(t, parsed.IsNumeric(), s)
+		assert.Equal(t, KindTime, parsed.Kind(), s)
+	}
+
+	parsed, err := p.ParseType("2021")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsPartialDate())
+	assert.Equal(t, PrecisionYear, parsed.PartialDate().Precision)
+	assert.Equal(t, KindInt, parsed.Kind())
+
+	for _, s := range []string{"1234", "0000", "1234100"} {
+		parsed, err = p.ParseType(s)
+		assert.NoError(t, err, s)
+		assert.False(t, parsed.IsTime(), s)
+	}
+
+	parsed, err = p.ParseType("2021-03-04")
+	assert.NoError(t, err)
+	assert.False(t, parsed.IsPartialDate())
+	assert.Nil(t, parsed.PartialDate())
+
+	r, err := NewRangeParser().ParseRange("Q1 2021 - Q3 2021")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), r.Start.Time)
+	assert.Equal(t, time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC), r.End.Time)
+	assert.False(t, r.HighInclusive)
+
+	_, err = NewCustomRangeParser(NewNumericParser(), NewCustomTimeParser(nil, nil)).ParseRange("Q1 2021-Q3 2021")
+	assert.Error(t, err)
+}
@@ -0,0 +1,240 @@
+package multiparse
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A PhoneRegion describes how phone numbers are written in a region:
+// its country calling code, the trunk prefix dialed before national
+// numbers within the region, and the range of lengths of its national
+// significant numbers.
+type PhoneRegion struct {
+	CallingCode string
+	TrunkPrefix string
+	MinLength   int
+	MaxLength   int
+}
+
+// PhoneRegions maps ISO 3166-1 alpha-2 region codes to the way phone
+// numbers are written in the region.  The table may be extended before
+// parsers are constructed.
+var PhoneRegions = map[string]PhoneRegion{
+	"US": {"1", "1", 10, 10},
+	"CA": {"1", "1", 10, 10},
+	"GB": {"44", "0", 9, 10},
+	"IE": {"353", "0", 7, 9},
+	"FR": {"33", "0", 9, 9},
+	"DE": {"49", "0", 6, 13},
+	"AT": {"43", "0", 4, 13},
+	"CH": {"41", "0", 9, 9},
+	"NL": {"31", "0", 9, 9},
+	"BE": {"32", "0", 8, 9},
+	"LU": {"352", "", 4, 11},
+	"ES": {"34", "", 9, 9},
+	"PT": {"351", "", 9, 9},
+	"IT": {"39", "", 6, 11},
+	"DK": {"45", "", 8, 8},
+	"NO": {"47", "", 8, 8},
+	"SE": {"46", "0", 7, 9},
+	"FI": {"358", "0", 5, 12},
+	"PL": {"48", "", 9, 9},
+	"CZ": {"420", "", 9, 9},
+	"GR": {"30", "", 10, 10},
+	"RU": {"7", "8", 10, 10},
+	"TR": {"90", "0", 10, 10},
+	"IL": {"972", "0", 8, 9},
+	"AE": {"971", "0", 8, 9},
+	"ZA": {"27", "0", 9, 9},
+	"NG": {"234", "0", 8, 10},
+	"IN": {"91", "0", 10, 10},
+	"CN": {"86", "0", 9, 11},
+	"HK": {"852", "", 8, 8},
+	"JP": {"81", "0", 9, 10},
+	"KR": {"82", "0", 8, 10},
+	"SG": {"65", "", 8, 8},
+	"AU": {"61", "0", 9, 9},
+	"NZ": {"64", "0", 8, 10},
+	"BR": {"55", "0", 10, 11},
+	"MX": {"52", "", 10, 10},
+	"AR": {"54", "0", 10, 11},
+}
+
+// phoneRegex matches the characters of a written phone number.
+var phoneRegex = regexp.MustCompile("^\\+?[0-9(][0-9 ().\\-/]*[0-9]$")
+
+// phoneDateRegex matches dates written with digits and separators, such
+// as "12-05-2019" and "2019.12.05", which are not phone numbers.
+var phoneDateRegex = regexp.MustCompile("^(\\d{4}[-./]\\d{1,2}[-./]\\d{1,2}|\\d{1,2}[-./]\\d{1,2}[-./](\\d{2}|\\d{4}))$")
+
+// A Phone is a phone number in E.164 form, e.g. "+14155550132", together
+// with its parts.
+type Phone struct {
+	E164           string
+	CountryCode    string
+	NationalNumber string
+	// Region is the region code of the number, or "" when several
+	// regions share its country code and none is the parser's default.
+	Region string
+}
+
+// A PhoneParser determines whether a string is a phone number, such as
+// "+1 (415) 555-0132" or "0044 20 7946 0958".  Numbers written without
+// an international prefix are read as numbers of the DefaultRegion, and
+// are rejected when it is not set.  To avoid reading plain integers and
+// dates as phone numbers, a number must have an international prefix or
+// be written with separators, as in "415.555.0132", and must not look
+// like a date.  National numbers must begin with the trunk prefix of the
+// DefaultRegion, as in "030 123456", except in regions with calling code
+// 1, where the prefix is usually omitted and the area code may not begin
+// with 0 or 1.  Regions without a trunk prefix only accept international
+// numbers.
+type PhoneParser struct {
+	DefaultRegion string
+	// Unexported fields.
+	regions map[string]PhoneRegion
+	codes   map[string][]string
+}
+
+// NewPhoneParser returns a PhoneParser without a default region that
+// uses the PhoneRegions table.
+func NewPhoneParser() *PhoneParser {
+	return NewCustomPhoneParser("", PhoneRegions)
+}
+
+// NewCustomPhoneParser returns a PhoneParser with the given default
+// region that uses the given table of regions.
+func NewCustomPhoneParser(defaultRegion string, regions map[string]PhoneRegion) *PhoneParser {
+	codes := make(map[string][]string)
+	for region, r := range regions {
+		codes[r.CallingCode] = append(codes[r.CallingCode], region)
+	}
+	for _, rs := range codes {
+		sort.Strings(rs)
+	}
+	return &PhoneParser{
+		DefaultRegion: defaultRegion,
+		regions:       regions,
+		codes:         codes,
+	}
+}
+
+// Parse a string to determine if it is a phone number.  The returned
+// value is a *Phone instance.
+func (p PhoneParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParsePhone is the same as Parse but returns a *Phone instance.
+func (p PhoneParser) ParsePhone(s string) (*Phone, error) {
+	return p.parse(s)
+}
+
+// digitsOnly removes every character of s that is not a digit.
+func digitsOnly(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// validParentheses reports whether s has at most one pair of
+// parentheses, in order.
+func validParentheses(s string) bool {
+	open, close := strings.Count(s, "("), strings.Count(s, ")")
+	if open != close || open > 1 {
+		return false
+	}
+	return open == 0 || strings.Index(s, "(") < strings.Index(s, ")")
+}
+
+func (p PhoneParser) parse(s string) (*Phone, error) {
+	parseErr := errors.New(ParsePhoneError)
+
+	s = strings.TrimSpace(s)
+	if !phoneRegex.MatchString(s) || !validParentheses(s) || phoneDateRegex.MatchString(s) {
+		return nil, parseErr
+	}
+
+	// Plain digit strings, such as "00442079460958", are not phone
+	// numbers, but "+442079460958" is.
+	digits := digitsOnly(s)
+	if len(digits) == len(s) {
+		return nil, parseErr
+	}
+	def, hasDefault := p.regions[p.DefaultRegion]
+
+	var international string
+	switch {
+	case strings.HasPrefix(s, "+"):
+		// The "(0)" in "+44 (0)20 7946 0958" is the trunk prefix.
+		international = digitsOnly(strings.Replace(s, "(0)", "", 1))
+	case strings.HasPrefix(digits, "00"):
+		international = digitsOnly(strings.Replace(s, "(0)", "", 1))[2:]
+	case hasDefault && def.CallingCode == "1" && strings.HasPrefix(digits, "011"):
+		international = digits[3:]
+	}
+
+	if international != "" {
+		for n := 1; n <= 3 && n < len(international); n++ {
+			code := international[:n]
+			if regions, prs := p.codes[code]; prs {
+				return p.phone(code, international[n:], regions)
+			}
+		}
+		return nil, parseErr
+	}
+
+	if !hasDefault || def.TrunkPrefix == "" {
+		return nil, parseErr
+	}
+	national := strings.TrimPrefix(digits, def.TrunkPrefix)
+	if def.CallingCode == "1" {
+		// North American numbers are usually written without the
+		// trunk prefix, as in "(415) 555-0132".
+		if national != digits && len(national) < def.MinLength {
+			national = digits
+		}
+		if national[0] == '0' || national[0] == '1' {
+			return nil, parseErr
+		}
+	} else if national == digits {
+		return nil, parseErr
+	}
+	return p.phone(def.CallingCode, national, p.codes[def.CallingCode])
+}
+
+// phone validates the length of a national number against the regions
+// with the given calling code.
+func (p PhoneParser) phone(code, national string, regions []string) (*Phone, error) {
+	var matches []string
+	for _, region := range regions {
+		r := p.regions[region]
+		if len(national) >= r.MinLength && len(national) <= r.MaxLength {
+			matches = append(matches, region)
+		}
+	}
+	if len(matches) == 0 || len(code)+len(national) > 15 {
+		return nil, errors.New(ParsePhoneError)
+	}
+
+	var region string
+	if len(matches) == 1 {
+		region = matches[0]
+	}
+	for _, m := range matches {
+		if m == p.DefaultRegion {
+			region = m
+		}
+	}
+	return &Phone{
+		E164:           "+" + code + national,
+		CountryCode:    code,
+		NationalNumber: national,
+		Region:         region,
+	}, nil
+}
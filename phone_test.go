@@ -0,0 +1,100 @@
+package multiparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoneParserParse(t *testing.T) {
+	tests := []struct {
+		in       string
+		region   string
+		e164     string
+		code     string
+		national string
+		out      string
+	}{
+		{"+1 (415) 555-0132", "", "+14155550132", "1", "4155550132", ""},
+		{"+14155550132", "", "+14155550132", "1", "4155550132", ""},
+		{"+1 (415) 555-0132", "US", "+14155550132", "1", "4155550132", "US"},
+		{"0044 20 7946 0958", "", "+442079460958", "44", "2079460958", "GB"},
+		{"+44 (0)20 7946 0958", "", "+442079460958", "44", "2079460958", "GB"},
+		{"+49 30 123456", "", "+4930123456", "49", "30123456", "DE"},
+		{"+353 1 234 5678", "", "+35312345678", "353", "12345678", "IE"},
+		{"415.555.0132", "US", "+14155550132", "1", "4155550132", "US"},
+		{"(415) 555-0132", "US", "+14155550132", "1", "4155550132", "US"},
+		{"1-415-555-0132", "US", "+14155550132", "1", "4155550132", "US"},
+		{"011 44 20 7946 0958", "US", "+442079460958", "44", "2079460958", "GB"},
+		{"020 7946 0958", "GB", "+442079460958", "44", "2079460958", "GB"},
+		{"030 123456", "DE", "+4930123456", "49", "30123456", "DE"},
+		{"8 (495) 123-45-67", "RU", "+74951234567", "7", "4951234567", "RU"},
+		{"06 12 34 56 78", "FR", "+33612345678", "33", "612345678", "FR"},
+	}
+
+	for _, tt := range tests {
+		p := NewCustomPhoneParser(tt.region, PhoneRegions)
+		ph, err := p.ParsePhone(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tt.e164, ph.E164, tt.in)
+		assert.Equal(t, tt.code, ph.CountryCode, tt.in)
+		assert.Equal(t, tt.national, ph.NationalNumber, tt.in)
+		assert.Equal(t, tt.out, ph.Region, tt.in)
+	}
+
+	fails := []struct {
+		in     string
+		region string
+	}{
+		{"415.555.0132", ""},
+		{"4155550132", "US"},
+		{"00442079460958", ""},
+		{"415.555", "US"},
+		{"12.5", "US"},
+		{"2019-12-05", "US"},
+		{"12/05/2019", "US"},
+		{"12:30", "US"},
+		{"1,234,567", "US"},
+		{"+999 123 456", ""},
+		{"+1 415 555 01329", ""},
+		{"(415 555-0132", "US"},
+		{"(415) (555) 0132", "US"},
+		{"call 415-555-0132", "US"},
+		{"", "US"},
+		{"12-05-2019", "DE"},
+		{"12.05.2019", "DE"},
+		{"2019-12-05", "DE"},
+		{"01.05.2019", "DE"},
+		{"1.234.567", "DE"},
+		{"1234 5678", "DE"},
+		{"30 123456", "DE"},
+		{"612 34 56 78", "ES"},
+		{"1.234.567", "US"},
+		{"(015) 555-0132", "US"},
+	}
+	for _, tt := range fails {
+		_, err := NewCustomPhoneParser(tt.region, PhoneRegions).Parse(tt.in)
+		assert.EqualError(t, err, ParsePhoneError, tt.in)
+	}
+}
+
+func TestParserRegisterPhoneParser(t *testing.T) {
+	p := NewParser()
+	p.Register(NewCustomPhoneParser("US", PhoneRegions))
+
+	parsed, err := p.ParseType("+14155550132")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsPhone())
+	assert.True(t, parsed.IsInt())
+	assert.Equal(t, KindPhone, parsed.Kind())
+	assert.Equal(t, "+14155550132", parsed.Phone().E164)
+
+	c := NewColumn("phone", p)
+	for _, s := range []string{"+1 (415) 555-0132", "415.555.0132", "+14155550100"} {
+		c.Add(s)
+	}
+	assert.Equal(t, KindPhone, c.Type().Kind)
+}
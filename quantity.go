@@ -0,0 +1,238 @@
+package multiparse
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// A Dimension is a kind of physical quantity, such as length or mass.
+type Dimension string
+
+// Dimensions of the units in the Units table.
+const (
+	DimensionLength      Dimension = "length"
+	DimensionMass        Dimension = "mass"
+	DimensionTime        Dimension = "time"
+	DimensionTemperature Dimension = "temperature"
+	DimensionData        Dimension = "data"
+)
+
+// BaseUnits are the units that quantities of each dimension are
+// normalized to.
+var BaseUnits = map[Dimension]string{
+	DimensionLength:      "m",
+	DimensionMass:        "kg",
+	DimensionTime:        "s",
+	DimensionTemperature: "K",
+	DimensionData:        "B",
+}
+
+// A Unit of measurement.  A value in the unit is converted to the base
+// unit of its dimension as value*Factor + Offset.
+type Unit struct {
+	Symbol    string
+	Dimension Dimension
+	Factor    float64
+	Offset    float64
+}
+
+// siPrefix is a metric prefix and the power of ten it denotes.
+type siPrefix struct {
+	symbol string
+	factor float64
+}
+
+var (
+	smallPrefixes = []siPrefix{
+		{"n", 1e-9}, {"µ", 1e-6}, {"u", 1e-6}, {"m", 1e-3},
+	}
+	largePrefixes = []siPrefix{
+		{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+	}
+	binaryPrefixes = []siPrefix{
+		{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30},
+		{"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	}
+)
+
+// Units maps unit symbols and names, such as "kg", "MiB", "°C" and
+// "miles", to units.  Metric units are included with the common SI
+// prefixes, and data sizes with both decimal ("MB") and binary ("MiB")
+// prefixes.  The table may be extended before parsers are constructed.
+var Units = newUnitTable()
+
+func newUnitTable() map[string]Unit {
+	m := make(map[string]Unit)
+	add := func(d Dimension, factor, offset float64, names ...string) {
+		for _, name := range names {
+			m[name] = Unit{Symbol: names[0], Dimension: d, Factor: factor, Offset: offset}
+		}
+	}
+	prefixed := func(d Dimension, symbol string, factor float64, prefixes ...[]siPrefix) {
+		for _, ps := range prefixes {
+			for _, p := range ps {
+				add(d, p.factor*factor, 0, p.symbol+symbol)
+			}
+		}
+	}
+
+	// Length, with metres as the base unit.
+	add(DimensionLength, 1, 0, "m", "meter", "meters", "metre", "metres")
+	prefixed(DimensionLength, "m", 1, smallPrefixes, largePrefixes)
+	add(DimensionLength, 1e-2, 0, "cm", "centimeter", "centimeters", "centimetre", "centimetres")
+	add(DimensionLength, 1e-3, 0, "mm", "millimeter", "millimeters", "millimetre", "millimetres")
+	add(DimensionLength, 1e3, 0, "km", "kilometer", "kilometers", "kilometre", "kilometres")
+	add(DimensionLength, 0.0254, 0, "in", "inch", "inches")
+	add(DimensionLength, 0.3048, 0, "ft", "foot", "feet")
+	add(DimensionLength, 0.9144, 0, "yd", "yard", "yards")
+	add(DimensionLength, 1609.344, 0, "mi", "mile", "miles")
+
+	// Mass, with kilograms as the base unit.
+	add(DimensionMass, 1e-3, 0, "g", "gram", "grams")
+	prefixed(DimensionMass, "g", 1e-3, smallPrefixes)
+	add(DimensionMass, 1, 0, "kg", "kilogram", "kilograms")
+	add(DimensionMass, 1e3, 0, "t", "tonne", "tonnes")
+	add(DimensionMass, 0.45359237, 0, "lb", "lbs", "pound", "pounds")
+	add(DimensionMass, 0.028349523125, 0, "oz", "ounce", "ounces")
+
+	// Time, with seconds as the base unit.
+	add(DimensionTime, 1, 0, "s", "sec", "secs", "second", "seconds")
+	prefixed(DimensionTime, "s", 1, smallPrefixes)
+	add(DimensionTime, 60, 0, "min", "mins", "minute", "minutes")
+	add(DimensionTime, 3600, 0, "h", "hr", "hrs", "hour", "hours")
+	add(DimensionTime, 86400, 0, "d", "day", "days")
+	add(DimensionTime, 604800, 0, "wk", "week", "weeks")
+
+	// Temperature, with kelvins as the base unit.
+	add(DimensionTemperature, 1, 0, "K", "kelvin", "kelvins")
+	add(DimensionTemperature, 1, 273.15, "°C", "℃", "celsius")
+	add(DimensionTemperature, 5.0/9, 273.15-32*5.0/9, "°F", "℉", "fahrenheit")
+
+	// Data sizes, with bytes as the base unit.
+	add(DimensionData, 1, 0, "B", "byte", "bytes")
+	prefixed(DimensionData, "B", 1, largePrefixes, binaryPrefixes)
+	add(DimensionData, 1e3, 0, "kB", "KB")
+	add(DimensionData, 0.125, 0, "b", "bit", "bits")
+	prefixed(DimensionData, "b", 0.125, largePrefixes)
+
+	return m
+}
+
+// A Quantity is a magnitude together with its unit of measurement.
+type Quantity struct {
+	Value float64
+	Unit  Unit
+}
+
+// Dimension of the quantity's unit.
+func (q Quantity) Dimension() Dimension {
+	return q.Unit.Dimension
+}
+
+// Base returns the quantity's value in the base unit of its dimension,
+// e.g. 1.5 for "1.5 kg" and 283.15 for "10°C".
+func (q Quantity) Base() float64 {
+	return q.Value*q.Unit.Factor + q.Unit.Offset
+}
+
+// BaseUnit returns the symbol of the base unit of the quantity's
+// dimension.
+func (q Quantity) BaseUnit() string {
+	return BaseUnits[q.Unit.Dimension]
+}
+
+// A QuantityParser determines whether a string such as "12 kg", "3.5 MB"
+// or "10°C" represents a physical quantity.  The magnitude is parsed
+// with a NumericParser and the unit is looked up in a unit table.
+// Units are case sensitive, but a unit written in a different case is
+// accepted when no other unit differs from it only by case, so that
+// "KM" is read as "km" while "mb" is rejected as either "MB" or "Mb".
+// Single letter units must be written in the correct case.
+type QuantityParser struct {
+	numeric *NumericParser
+	units   map[string]Unit
+	folded  map[string]Unit
+}
+
+// NewQuantityParser returns a QuantityParser that uses the general
+// NumericParser and the Units table.
+func NewQuantityParser() *QuantityParser {
+	return NewCustomQuantityParser(NewNumericParser(), Units)
+}
+
+// NewCustomQuantityParser returns a QuantityParser that parses
+// magnitudes with the given NumericParser and recognizes the units in
+// the given table.
+func NewCustomQuantityParser(numeric *NumericParser, units map[string]Unit) *QuantityParser {
+	folded := make(map[string]Unit)
+	ambiguous := make(map[string]bool)
+	for name, u := range units {
+		key := strings.ToLower(name)
+		if other, prs := folded[key]; prs && other != u {
+			ambiguous[key] = true
+		}
+		folded[key] = u
+	}
+	for key := range ambiguous {
+		delete(folded, key)
+	}
+
+	return &QuantityParser{
+		numeric: numeric,
+		units:   units,
+		folded:  folded,
+	}
+}
+
+// Parse a string to determine if it represents a physical quantity.
+// The returned value is a *Quantity instance.
+func (p QuantityParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParseQuantity is the same as Parse but returns a *Quantity instance.
+func (p QuantityParser) ParseQuantity(s string) (*Quantity, error) {
+	return p.parse(s)
+}
+
+// splitUnit splits a string such as "12 kg" into its magnitude and the
+// unit following the last digit.
+func splitUnit(s string) (string, string) {
+	i := strings.LastIndexFunc(s, unicode.IsDigit)
+	if i < 0 {
+		return "", ""
+	}
+	return strings.TrimSpace(s[:i+1]), strings.TrimSpace(s[i+1:])
+}
+
+func (p QuantityParser) lookup(name string) (Unit, bool) {
+	if u, prs := p.units[name]; prs {
+		return u, true
+	}
+	// Single letters, such as "M" and "m", are too easily confused.
+	if len([]rune(name)) < 2 {
+		return Unit{}, false
+	}
+	u, prs := p.folded[strings.ToLower(name)]
+	return u, prs
+}
+
+func (p QuantityParser) parse(s string) (*Quantity, error) {
+	parseErr := errors.New(ParseQuantityError)
+
+	magnitude, name := splitUnit(strings.TrimSpace(s))
+	if name == "" {
+		return nil, parseErr
+	}
+	u, ok := p.lookup(name)
+	if !ok {
+		return nil, parseErr
+	}
+	n, err := p.numeric.parse(magnitude)
+	if err != nil || n.IsMoney() {
+		return nil, parseErr
+	}
+
+	return &Quantity{Value: n.Float(), Unit: u}, nil
+}
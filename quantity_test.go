@@ -0,0 +1,92 @@
+package multiparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuantityParserParse(t *testing.T) {
+	tests := []struct {
+		in        string
+		value     float64
+		unit      string
+		dimension Dimension
+		base      float64
+	}{
+		{"12 kg", 12, "kg", DimensionMass, 12},
+		{"500g", 500, "g", DimensionMass, 0.5},
+		{"3.5 MB", 3.5, "MB", DimensionData, 3.5e6},
+		{"1.5GiB", 1.5, "GiB", DimensionData, 1.5 * (1 << 30)},
+		{"8 bits", 8, "b", DimensionData, 1},
+		{"10°C", 10, "°C", DimensionTemperature, 283.15},
+		{"-40 °F", -40, "°F", DimensionTemperature, 233.15},
+		{"300 K", 300, "K", DimensionTemperature, 300},
+		{"2.5 km", 2.5, "km", DimensionLength, 2500},
+		{"1,200 miles", 1200, "mi", DimensionLength, 1931212.8},
+		{"12 KM", 12, "km", DimensionLength, 12000},
+		{"250 ms", 250, "ms", DimensionTime, 0.25},
+		{"2 hours", 2, "h", DimensionTime, 7200},
+		{"5 µm", 5, "µm", DimensionLength, 5e-6},
+	}
+
+	p := NewQuantityParser()
+	for _, tt := range tests {
+		q, err := p.ParseQuantity(tt.in)
+		if !assert.NoError(t, err, tt.in) {
+			continue
+		}
+		assert.Equal(t, tt.value, q.Value, tt.in)
+		assert.Equal(t, tt.unit, q.Unit.Symbol, tt.in)
+		assert.Equal(t, tt.dimension, q.Dimension(), tt.in)
+		assert.InDelta(t, tt.base, q.Base(), 1e-9*tt.base, tt.in)
+		assert.Equal(t, BaseUnits[tt.dimension], q.BaseUnit(), tt.in)
+	}
+
+	fails := []string{"12", "kg", "12 parsecs", "3 mb", "3M", "$12 kg", "abc kg", ""}
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.Error(t, err, tt)
+	}
+}
+
+func TestCustomQuantityParser(t *testing.T) {
+	units := map[string]Unit{
+		"px": {Symbol: "px", Dimension: "screen", Factor: 1},
+	}
+	p := NewCustomQuantityParser(NewCustomNumericParser("", ".", ","), units)
+	q, err := p.ParseQuantity("1.024,5 px")
+	assert.NoError(t, err)
+	assert.Equal(t, 1024.5, q.Value)
+	_, err = p.ParseQuantity("12 kg")
+	assert.Error(t, err)
+}
+
+func TestParserRegisterQuantity(t *testing.T) {
+	p := NewParser()
+	_, err := p.ParseType("12 kg")
+	assert.Error(t, err)
+
+	p.Register(NewQuantityParser())
+	parsed, err := p.ParseType("12 kg")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsQuantity())
+	assert.False(t, parsed.IsNumeric())
+	assert.Equal(t, KindQuantity, parsed.Kind())
+	assert.Equal(t, 12.0, parsed.Quantity().Value)
+
+	parsed, err = p.ParseType("12")
+	assert.NoError(t, err)
+	assert.False(t, parsed.IsQuantity())
+	assert.Nil(t, parsed.Quantity())
+
+	c := NewColumn("weight", p)
+	for _, s := range []string{"12 kg", "3 kg", "500 g", "n/a"} {
+		c.Add(s)
+	}
+	assert.Equal(t, "quantity kg, 75% coverage", c.Type().String())
+
+	p.Register(new(BadNumericParser))
+	_, err = p.ParseType("12 kg")
+	assert.EqualError(t, err, ParseTypeAssertError)
+}
@@ -0,0 +1,229 @@
+package multiparse
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// rangeSeparators are the separators between the endpoints of a range,
+// longest first so that ".." is not mistaken for "." and " to " wins
+// over the hyphen in "-5 to 5".
+var rangeSeparators = []string{
+	" through ", " thru ", " to ", "...", "..", "–", "—", "-", "/",
+}
+
+// monthNameRegex matches a month name without a year, as in the "Jan"
+// of "Jan–Mar 2021".
+var monthNameRegex = regexp.MustCompile("^[A-Za-z]{3,9}\\.?$")
+
+// A Range is an interval between two numbers, as in "10 - 20" and
+// "$5 – $10", or between two times, as in "2020-01-01/2020-03-31" and
+// "Jan–Mar 2021".  Numeric ranges have Low and High values and time
+// ranges Start and End values.  Endpoints are inclusive unless the range
+// is written in interval notation with parentheses, as in "[0, 1)".  A
+// high endpoint that names a period, such as the "Mar 2021" of
+// "Jan–Mar 2021", includes the whole period, so End is the start of the
+// following period, April 1, and HighInclusive is false.
+type Range struct {
+	Low           *Numeric
+	High          *Numeric
+	Start         *Time
+	End           *Time
+	LowInclusive  bool
+	HighInclusive bool
+	// Separator is the separator between the endpoints as written, such
+	// as "-", " to " or "/".
+	Separator string
+}
+
+// IsNumeric reports if the range is between two numbers.
+func (r Range) IsNumeric() bool {
+	return r.Low != nil
+}
+
+// IsTime reports if the range is between two times.
+func (r Range) IsTime() bool {
+	return r.Start != nil
+}
+
+// A RangeParser determines whether a string is a range by splitting it
+// at a separator such as "-", "–", " to ", ".." or "/" and parsing both
+// endpoints with its NumericParser or both with its TimeParser.  The low
+// endpoint may not be greater than the high one.  Strings that parse as
+// a single number, such as "-5", are not ranges, and neither are digit
+// strings with leading zeros, so "02134-1234" is not a range.  A hyphen
+// between two integers must have spaces around it, as in "10 - 20",
+// unless an endpoint has a currency sign or decimal point, as in
+// "$10-$20" and "1.5-2.5", so phone and part numbers such as "555-1234"
+// and "10-20" are not ranges.  The "/" separator of ISO 8601 intervals
+// is only accepted between times, since "1/2" is a fraction.
+type RangeParser struct {
+	numeric *NumericParser
+	time    *TimeParser
+}
+
+// NewRangeParser returns a RangeParser that parses endpoints with the
+// parsers returned by NewNumericParser and NewTimeParser.
+func NewRangeParser() *RangeParser {
+	return NewCustomRangeParser(NewNumericParser(), NewTimeParser())
+}
+
+// NewCustomRangeParser returns a RangeParser that parses endpoints with
+// the given parsers.
+func NewCustomRangeParser(numeric *NumericParser, time *TimeParser) *RangeParser {
+	return &RangeParser{
+		numeric: numeric,
+		time:    time,
+	}
+}
+
+// Parse a string to determine if it is a range.  The returned value is
+// a *Range instance.
+func (p RangeParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParseRange is the same as Parse but returns a *Range instance.
+func (p RangeParser) ParseRange(s string) (*Range, error) {
+	return p.parse(s)
+}
+
+func (p RangeParser) parse(s string) (*Range, error) {
+	parseErr := errors.New(ParseRangeError)
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, parseErr
+	}
+	if _, err := p.numeric.ParseNumeric(s); err == nil {
+		return nil, parseErr
+	}
+
+	if r := p.parseInterval(s); r != nil {
+		return r, nil
+	}
+
+	for i := 1; i < len(s); i++ {
+		for _, sep := range rangeSeparators {
+			if len(s)-i < len(sep) || !strings.EqualFold(s[i:i+len(sep)], sep) {
+				continue
+			}
+			low, high := s[:i], s[i+len(sep):]
+			tight := sep == "-" && !strings.HasSuffix(low, " ") && !strings.HasPrefix(high, " ")
+			if r := p.endpoints(low, high, sep == "/", tight); r != nil {
+				r.LowInclusive, r.HighInclusive = true, true
+				r.Separator = sep
+				r.closeEnd()
+				return r, nil
+			}
+		}
+	}
+	return nil, parseErr
+}
+
+// parseInterval parses a range in interval notation, such as "[1, 5)",
+// in which a parenthesis excludes its endpoint.
+func (p RangeParser) parseInterval(s string) *Range {
+	first, last := s[0], s[len(s)-1]
+	if (first != '[' && first != '(') || (last != ']' && last != ')') {
+		return nil
+	}
+	inner := s[1 : len(s)-1]
+	for _, sep := range []string{", ", ",", ";"} {
+		if strings.Count(inner, sep) != 1 {
+			continue
+		}
+		i := strings.Index(inner, sep)
+		if r := p.endpoints(inner[:i], inner[i+len(sep):], false, false); r != nil {
+			r.LowInclusive = first == '['
+			r.HighInclusive = last == ']'
+			r.Separator = sep
+			r.closeEnd()
+			return r
+		}
+	}
+	return nil
+}
+
+// closeEnd replaces an inclusive End that names a period, such as
+// "Mar 2021", with the exclusive start of the following period.
+func (r *Range) closeEnd() {
+	if r.End == nil || !r.HighInclusive {
+		return
+	}
+	if d := r.End.PartialDate(); d != nil {
+		end := *r.End
+		end.Time = d.End
+		r.End, r.HighInclusive = &end, false
+	}
+}
+
+// endpoints parses the endpoints of a range, returning nil unless both
+// are numbers or both are times and they are in order.  Integers joined
+// by a tight hyphen, as in "555-1234", are more likely phone or part
+// numbers than ranges, so they are rejected.
+func (p RangeParser) endpoints(low, high string, timesOnly, tight bool) *Range {
+	low, high = strings.TrimSpace(low), strings.TrimSpace(high)
+	if low == "" || high == "" {
+		return nil
+	}
+
+	// Times are tried first, since the NumericParser reads the "Jan" of
+	// "Jan 2021" as a currency code.
+	if r := p.timeEndpoints(low, high); r != nil || timesOnly {
+		return r
+	}
+
+	l, errL := p.numeric.ParseNumeric(low)
+	h, errH := p.numeric.ParseNumeric(high)
+	if errL != nil || errH != nil || l.IsCode() || h.IsCode() || l.Float() > h.Float() {
+		return nil
+	}
+	if tight && l.IsInt() && h.IsInt() && !l.IsMoney() && !h.IsMoney() {
+		return nil
+	}
+	return &Range{Low: l, High: h}
+}
+
+// timeEndpoints parses the endpoints of a range of times, returning nil
+// unless both are times and they are in order.
+func (p RangeParser) timeEndpoints(low, high string) *Range {
+	end := p.parseTime(high)
+	if end == nil {
+		return nil
+	}
+	start := p.parseTime(low)
+	if start == nil && monthNameRegex.MatchString(low) && strings.Contains(end.layout, "2006") {
+		// The year of "Jan–Mar 2021" is written only once.
+		start = p.parseTime(low + " " + end.Format("2006"))
+	}
+	if start == nil || start.After(end.Time) {
+		return nil
+	}
+	return &Range{Start: start, End: end}
+}
+
+// parseTime parses an endpoint with the TimeParser's layouts and ISO
+// 8601 formats, as a month such as "Mar 2021", or, if the TimeParser
+// accepts partial dates, as a period such as "Q3 2021".  Unlike the
+// TimeParser, it does not accept strings that merely begin with a date,
+// such as "2020-01-01/2020-03-31", or years, so "1000-2000" is a range
+// of numbers.
+func (p RangeParser) parseTime(s string) *Time {
+	for _, layouts := range [][]string{p.time.timeLayouts, p.time.dateLayouts, monthYearLayouts} {
+		if t := parseLayouts(layouts, s); t != nil {
+			return t
+		}
+	}
+	if t, ok := parseISO8601(s); ok && p.time.ISO8601 && t.precision != PrecisionYear {
+		return t
+	}
+	if !p.time.PartialDates {
+		return nil
+	}
+	if t := p.time.parsePeriod(s); t != nil && t.precision != PrecisionYear {
+		return t
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package multiparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeParserParseNumeric(t *testing.T) {
+	tests := []struct {
+		in                string
+		low, high         float64
+		lowInc, highInc   bool
+		sep               string
+		lowMoney, hiMoney bool
+	}{
+		{"10 - 20", 10, 20, true, true, "-", false, false},
+		{"10–20", 10, 20, true, true, "–", false, false},
+		{"$10-$20", 10, 20, true, true, "-", true, true},
+		{"1.5-2.5", 1.5, 2.5, true, true, "-", false, false},
+		{"$5 – $10", 5, 10, true, true, "–", true, true},
+		{"1,000 to 2,000", 1000, 2000, true, true, " to ", false, false},
+		{"1,000 TO 2,000", 1000, 2000, true, true, " to ", false, false},
+		{"-10 - 20", -10, 20, true, true, "-", false, false},
+		{"-10 - -5", -10, -5, true, true, "-", false, false},
+		{"-5 to 5", -5, 5, true, true, " to ", false, false},
+		{"1.5..2.5", 1.5, 2.5, true, true, "..", false, false},
+		{"[0, 1)", 0, 1, true, false, ", ", false, false},
+		{"(1.5,3]", 1.5, 3, false, true, ",", false, false},
+		{"5 - 5", 5, 5, true, true, "-", false, false},
+	}
+
+	p := NewRangeParser()
+	for _, tt := range tests {
+		r, err := p.ParseRange(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.True(t, r.IsNumeric(), tt.in)
+		assert.False(t, r.IsTime(), tt.in)
+		assert.Equal(t, tt.low, r.Low.Float(), tt.in)
+		assert.Equal(t, tt.high, r.High.Float(), tt.in)
+		assert.Equal(t, tt.lowInc, r.LowInclusive, tt.in)
+		assert.Equal(t, tt.highInc, r.HighInclusive, tt.in)
+		assert.Equal(t, tt.sep, r.Separator, tt.in)
+		assert.Equal(t, tt.lowMoney, r.Low.IsMoney(), tt.in)
+		assert.Equal(t, tt.hiMoney, r.High.IsMoney(), tt.in)
+	}
+}
+
+func TestRangeParserParseTime(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		in         string
+		start, end time.Time
+		highInc    bool
+		sep        string
+	}{
+		{"2020-01-01/2020-03-31", date(2020, 1, 1), date(2020, 3, 31), true, "/"},
+		{"2020-01-01 - 2020-03-31", date(2020, 1, 1), date(2020, 3, 31), true, "-"},
+		{"2020-01-01 to 2020-03-31", date(2020, 1, 1), date(2020, 3, 31), true, " to "},
+		{"Jan–Mar 2021", date(2021, 1, 1), date(2021, 4, 1), false, "–"},
+		{"Jan 2021 - Mar 2021", date(2021, 1, 1), date(2021, 4, 1), false, "-"},
+		{"January through March 2021", date(2021, 1, 1), date(2021, 4, 1), false, " through "},
+		{"2021-01 to 2021-12", date(2021, 1, 1), date(2022, 1, 1), false, " to "},
+		{"[Jan 2021, Mar 2021)", date(2021, 1, 1), date(2021, 3, 1), false, ", "},
+		{
+			"2020-01-01T10:00:00Z/2020-01-01T12:30:00Z",
+			time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+			time.Date(2020, 1, 1, 12, 30, 0, 0, time.UTC),
+			true,
+			"/",
+		},
+	}
+
+	p := NewRangeParser()
+	for _, tt := range tests {
+		r, err := p.ParseRange(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.True(t, r.IsTime(), tt.in)
+		assert.False(t, r.IsNumeric(), tt.in)
+		assert.True(t, tt.start.Equal(r.Start.Time), tt.in)
+		assert.True(t, tt.end.Equal(r.End.Time), tt.in)
+		assert.True(t, r.LowInclusive, tt.in)
+		assert.Equal(t, tt.highInc, r.HighInclusive, tt.in)
+		assert.Equal(t, tt.sep, r.Separator, tt.in)
+	}
+}
+
+func TestRangeParserParseFails(t *testing.T) {
+	fails := []string{
+		"", "10", "-5", "1,000", "2020-01-01", "1-2-2006", "2006/01/02",
+		"20 - 10", "02134-1234", "10-20", "555-1234", "-10-20", "5-5", "1/2", "10/20", "415-555-0132",
+		"2020-03-31/2020-01-01", "Mar–Jan 2021", "10-abc", "a-b", "-",
+		"10-", "-10-", "[1, 2, 3]", "(5)", "1 - 2020-01-01",
+	}
+	p := NewRangeParser()
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.EqualError(t, err, ParseRangeError, tt)
+	}
+}
+
+func TestParserRegisterRangeParser(t *testing.T) {
+	p := NewParser()
+	p.Register(NewRangeParser())
+
+	parsed, err := p.ParseType("10 - 20")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsRange())
+	assert.Equal(t, KindRange, parsed.Kind())
+	assert.Equal(t, 20, parsed.Range().High.Int())
+
+	parsed, err = p.ParseType("2020-01-01/2020-03-31")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsRange())
+	assert.False(t, parsed.IsTime())
+	assert.Equal(t, KindRange, parsed.Kind())
+
+	parsed, err = p.ParseType("2020-01-01")
+	assert.NoError(t, err)
+	assert.False(t, parsed.IsRange())
+	assert.Equal(t, KindTime, parsed.Kind())
+
+	parsed, err = p.ParseType("-5")
+	assert.NoError(t, err)
+	assert.False(t, parsed.IsRange())
+	assert.Equal(t, KindInt, parsed.Kind())
+
+	c := NewColumn("price", p)
+	for _, s := range []string{"$5 – $10", "$10 – $20", "TBD"} {
+		c.Add(s)
+	}
+	assert.Equal(t, "range, 66% coverage", c.Type().String())
+}
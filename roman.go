@@ -0,0 +1,141 @@
+package multiparse
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// romanRegex matches Roman numerals in canonical form, from I to
+// MMMCMXCIX.
+var romanRegex = regexp.MustCompile("^M{0,3}(?:CM|CD|D?C{0,3})(?:XC|XL|L?X{0,3})(?:IX|IV|V?I{0,3})$")
+
+var romanValues = map[rune]int{
+	'I': 1, 'V': 5, 'X': 10, 'L': 50, 'C': 100, 'D': 500, 'M': 1000,
+}
+
+// romanNumerals are the symbols of the canonical form, largest first.
+var romanNumerals = []struct {
+	symbol string
+	value  int
+}{
+	{"M", 1000}, {"CM", 900}, {"D", 500}, {"CD", 400},
+	{"C", 100}, {"XC", 90}, {"L", 50}, {"XL", 40},
+	{"X", 10}, {"IX", 9}, {"V", 5}, {"IV", 4}, {"I", 1},
+}
+
+// A RomanParser determines whether a string is a Roman numeral, such as
+// "XIV" or "mcmxcix".  By default only the canonical form is accepted,
+// written entirely in upper or lower case.  A lenient parser also
+// accepts the additive form, in which symbols never increase, as in
+// "IIII" and "MDCCCCX", and numerals in mixed case.
+//
+// Since strings such as "I" and "MIX" are also words, a RomanParser is
+// not part of the general Parser, but may be registered with one.
+type RomanParser struct {
+	Lenient bool
+}
+
+// NewRomanParser returns a RomanParser that accepts only the canonical
+// form.
+func NewRomanParser() *RomanParser {
+	return &RomanParser{}
+}
+
+// Parse a string to determine if it is a Roman numeral.  The returned
+// value is a *Numeric instance.
+func (p RomanParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParseNumeric is the same as Parse but returns a *Numeric instance.
+func (p RomanParser) ParseNumeric(s string) (*Numeric, error) {
+	return p.parse(s)
+}
+
+func (p RomanParser) parse(s string) (*Numeric, error) {
+	parseErr := errors.New(ParseRomanError)
+
+	text := s
+	s = strings.TrimSpace(s)
+	upper := strings.ToUpper(s)
+	lower := strings.ToLower(s)
+	if s == "" || (!p.Lenient && s != upper && s != lower) {
+		return nil, parseErr
+	}
+
+	var n int
+	switch {
+	case romanRegex.MatchString(upper):
+		n = romanValue(upper)
+	case p.Lenient && additiveRoman(upper):
+		n = romanValue(upper)
+	default:
+		return nil, parseErr
+	}
+
+	style := "I"
+	if s == lower {
+		style = "i"
+	}
+	return &Numeric{
+		isInt:     true,
+		isFloat:   true,
+		f:         float64(n),
+		text:      text,
+		sanitized: strconv.Itoa(n),
+		format:    numberFormat{roman: style},
+	}, nil
+}
+
+// additiveRoman reports whether s is a Roman numeral in which the
+// symbols never increase in value.
+func additiveRoman(s string) bool {
+	last := 0
+	for _, r := range s {
+		v, prs := romanValues[r]
+		if !prs || (last != 0 && v > last) {
+			return false
+		}
+		last = v
+	}
+	return true
+}
+
+// romanValue returns the value of a valid Roman numeral in upper case.
+// A symbol is subtracted when a larger one follows it.
+func romanValue(s string) int {
+	n := 0
+	runes := []rune(s)
+	for i, r := range runes {
+		v := romanValues[r]
+		if i+1 < len(runes) && romanValues[runes[i+1]] > v {
+			n -= v
+		} else {
+			n += v
+		}
+	}
+	return n
+}
+
+// formatRoman returns the canonical Roman numeral for n, or "" if n is
+// not between 1 and 3999.
+func formatRoman(n int) string {
+	if n < 1 || n > 3999 {
+		return ""
+	}
+	var b strings.Builder
+	for _, rn := range romanNumerals {
+		for n >= rn.value {
+			b.WriteString(rn.symbol)
+			n -= rn.value
+		}
+	}
+	return b.String()
+}
+
+// IsRoman reports if the original string is a Roman numeral.
+func (x Numeric) IsRoman() bool {
+	return x.format.roman != ""
+}
@@ -0,0 +1,96 @@
+package multiparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRomanParserParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		strict  int
+		lenient int
+	}{
+		{"I", 1, 1},
+		{"IV", 4, 4},
+		{"IX", 9, 9},
+		{"XIV", 14, 14},
+		{"XL", 40, 40},
+		{"XC", 90, 90},
+		{"CD", 400, 400},
+		{"MIX", 1009, 1009},
+		{"MCMXCIX", 1999, 1999},
+		{"mcmxcix", 1999, 1999},
+		{" xiv ", 14, 14},
+		{"MMMCMXCIX", 3999, 3999},
+		{"IIII", 0, 4},
+		{"VIIII", 0, 9},
+		{"XXXXX", 0, 50},
+		{"MDCCCCX", 0, 1910},
+		{"MMMM", 0, 4000},
+		{"McmXcix", 0, 1999},
+		{"IIV", 0, 0},
+		{"IL", 0, 0},
+		{"VX", 0, 0},
+		{"IXI", 0, 0},
+		{"", 0, 0},
+		{"ABC", 0, 0},
+		{"12", 0, 0},
+	}
+
+	strict := NewRomanParser()
+	lenient := &RomanParser{Lenient: true}
+	for _, tt := range tests {
+		n, err := strict.ParseNumeric(tt.in)
+		if tt.strict == 0 {
+			assert.EqualError(t, err, ParseRomanError, tt.in)
+		} else {
+			assert.NoError(t, err, tt.in)
+			assert.Equal(t, tt.strict, n.Int(), tt.in)
+			assert.True(t, n.IsInt(), tt.in)
+			assert.True(t, n.IsRoman(), tt.in)
+		}
+
+		n, err = lenient.ParseNumeric(tt.in)
+		if tt.lenient == 0 {
+			assert.EqualError(t, err, ParseRomanError, tt.in)
+		} else {
+			assert.NoError(t, err, tt.in)
+			assert.Equal(t, tt.lenient, n.Int(), tt.in)
+		}
+	}
+}
+
+func TestFormatRoman(t *testing.T) {
+	for n := 1; n < 4000; n++ {
+		r := formatRoman(n)
+		x, err := NewRomanParser().ParseNumeric(r)
+		assert.NoError(t, err, r)
+		assert.Equal(t, n, x.Int(), r)
+	}
+	assert.Equal(t, "", formatRoman(0))
+	assert.Equal(t, "", formatRoman(4000))
+}
+
+func TestParserRegisterRomanParser(t *testing.T) {
+	// Roman numerals are not detected unless registered.
+	_, err := Parse("XIV")
+	assert.EqualError(t, err, ParseError)
+
+	p := NewParser()
+	p.Register(NewRomanParser())
+	parsed, err := p.ParseType("XIV")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsRoman())
+	assert.Equal(t, KindInt, parsed.Kind())
+
+	f := NewFormatter(parsed)
+	assert.Equal(t, "XV", f.FormatInt(15))
+	assert.Equal(t, "4000", f.FormatInt(4000))
+	assert.Equal(t, "2.5", f.FormatFloat(2.5))
+
+	parsed, err = p.ParseType("xiv")
+	assert.NoError(t, err)
+	assert.Equal(t, "mmxxvi", NewFormatter(parsed).FormatInt(2026))
+}
@@ -0,0 +1,241 @@
+package multiparse
+
+import (
+	"errors"
+	"regexp"
+)
+
+// numberShape decomposes a numeric string such as "1,234.56" into its
+// digit groups ("1", "234", "56") and the separators between them
+// (",", ".").
+type numberShape struct {
+	groups []string
+	seps   []string
+}
+
+// separatorInference records which separator a numeric string uses to
+// group digits and which marks the decimal point.  An empty string
+// means the string does not contain that kind of separator.
+type separatorInference struct {
+	digit     string
+	decimal   string
+	ambiguous bool
+}
+
+// A separatorRule recognizes a numeric shape and infers the roles its
+// separators play.
+type separatorRule struct {
+	name  string
+	match func(numberShape) bool
+	infer func(numberShape) separatorInference
+}
+
+// separatorRules is the separator inference algorithm used when a
+// NumericParser cannot distinguish its digit and decimal separators.
+// The rules are tried in order and the first match wins:
+//
+//	none:       "1234"      no separators, the number is an integer.
+//	mixed:      "1,234.56"  two distinct separators; the last one is the
+//	                        decimal separator and occurs exactly once.
+//	repeated:   "1.234.567" one separator occurring several times must
+//	                        group digits.
+//	leading 0:  "0,123"     a zero integer part cannot be grouped.
+//	long head:  "1234.567"  more than three leading digits cannot be a group.
+//	short tail: "12,5"      a trailing group that is not three digits long
+//	                        cannot be a group.
+//	ambiguous:  "123.456"   one separator between 1-3 digits and exactly
+//	                        3 digits.  Either reading is plausible, so it
+//	                        is reported as ambiguous and read as a
+//	                        digit separator.
+//
+// Once the roles are known, the integer part must be grouped in either
+// the Western style ("1,234,567") or the Indian style ("12,34,567").
+var separatorRules = []separatorRule{
+	{
+		name:  "none",
+		match: func(n numberShape) bool { return len(n.seps) == 0 },
+		infer: func(n numberShape) separatorInference { return separatorInference{} },
+	},
+	{
+		name: "mixed",
+		match: func(n numberShape) bool {
+			for _, sep := range n.seps {
+				if sep != n.seps[0] {
+					return true
+				}
+			}
+			return false
+		},
+		infer: func(n numberShape) separatorInference {
+			return separatorInference{
+				digit:   n.seps[0],
+				decimal: n.seps[len(n.seps)-1],
+			}
+		},
+	},
+	{
+		name:  "repeated",
+		match: func(n numberShape) bool { return len(n.seps) > 1 },
+		infer: func(n numberShape) separatorInference {
+			return separatorInference{digit: n.seps[0]}
+		},
+	},
+	{
+		name:  "leading 0",
+		match: func(n numberShape) bool { return n.groups[0] == "0" },
+		infer: inferDecimal,
+	},
+	{
+		name:  "long head",
+		match: func(n numberShape) bool { return len(n.groups[0]) > 3 },
+		infer: inferDecimal,
+	},
+	{
+		name:  "short tail",
+		match: func(n numberShape) bool { return len(n.groups[1]) != 3 },
+		infer: inferDecimal,
+	},
+	{
+		name:  "ambiguous",
+		match: func(n numberShape) bool { return true },
+		infer: func(n numberShape) separatorInference {
+			return separatorInference{digit: n.seps[0], ambiguous: true}
+		},
+	},
+}
+
+func inferDecimal(n numberShape) separatorInference {
+	return separatorInference{decimal: n.seps[0]}
+}
+
+var allDigitsRegex = regexp.MustCompile("^\\d+$")
+
+// newNumberShape splits s at every match of the separator regex.
+// Strings with empty digit groups, such as "1,,2", are invalid.
+func newNumberShape(s string, sep *regexp.Regexp) (numberShape, error) {
+	var n numberShape
+	start := 0
+	for _, loc := range sep.FindAllStringIndex(s, -1) {
+		n.groups = append(n.groups, s[start:loc[0]])
+		n.seps = append(n.seps, s[loc[0]:loc[1]])
+		start = loc[1]
+	}
+	n.groups = append(n.groups, s[start:])
+
+	for _, g := range n.groups {
+		if !allDigitsRegex.MatchString(g) {
+			return n, errors.New(ParseNumericError)
+		}
+	}
+	return n, nil
+}
+
+// validGrouping reports whether the digit groups of an integer part use
+// either the Western or Indian grouping convention.
+func validGrouping(groups []string) bool {
+	return len(groups) == 1 || westernGrouping(groups) || indianGrouping(groups)
+}
+
+// westernGrouping reports whether the digit groups look like 1,234,567:
+// 1-3 digits followed by groups of 3.
+func westernGrouping(groups []string) bool {
+	if len(groups[0]) > 3 {
+		return false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// indianGrouping reports whether the digit groups look like 12,34,567:
+// 1-2 digits, groups of 2, and a final group of 3.
+func indianGrouping(groups []string) bool {
+	last := len(groups) - 1
+	if last == 0 || len(groups[0]) > 2 || len(groups[last]) != 3 {
+		return false
+	}
+	for _, g := range groups[1:last] {
+		if len(g) != 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// inferSeparators applies the separatorRules to s, which should be
+// stripped of currency symbols and signs, and validates the result.
+func inferSeparators(s string, sep *regexp.Regexp) (separatorInference, error) {
+	var inf separatorInference
+	n, err := newNumberShape(s, sep)
+	if err != nil {
+		return inf, err
+	}
+
+	for _, rule := range separatorRules {
+		if rule.match(n) {
+			inf = rule.infer(n)
+			break
+		}
+	}
+
+	// Every separator but a final decimal separator must be the digit
+	// separator, and the decimal separator may occur only once.
+	intGroups := n.groups
+	groupSeps := n.seps
+	if inf.decimal != "" {
+		intGroups = n.groups[:len(n.groups)-1]
+		groupSeps = n.seps[:len(n.seps)-1]
+	}
+	for _, sep := range groupSeps {
+		if sep != inf.digit {
+			return inf, errors.New(ParseMoneySeparatorError)
+		}
+	}
+	if !validGrouping(intGroups) {
+		return inf, errors.New(ParseMoneySeparatorError)
+	}
+
+	return inf, nil
+}
+
+// LearnNumericParser infers the digit and decimal separators shared by
+// a column of values, such as the entries of a spreadsheet column, and
+// returns a NumericParser configured with them.  Each value that
+// unambiguously identifies a separator casts a vote for either the
+// "1,234.5" or "1.234,5" convention and the majority wins, so that
+// ambiguous values like "1.234" are then parsed consistently with
+// the rest of the column.  When no value is decisive the parser uses
+// the same separators as NewNumericParser.  An error is returned when
+// the conventions are tied.
+func LearnNumericParser(values []string) (*NumericParser, error) {
+	u := NewCustomNumericParser("", "", "")
+	var commaDigit, dotDigit int
+	for _, v := range values {
+		body, _, _, err := u.normalize(v)
+		if err != nil {
+			continue
+		}
+		inf, err := inferSeparators(body, u.digitRegex)
+		if err != nil || inf.ambiguous {
+			continue
+		}
+		switch {
+		case inf.digit == "," || inf.decimal == ".":
+			commaDigit++
+		case inf.digit == "." || inf.decimal == ",":
+			dotDigit++
+		}
+	}
+
+	switch {
+	case commaDigit > 0 && commaDigit == dotDigit:
+		return nil, errors.New(ParseMoneySeparatorError)
+	case dotDigit > commaDigit:
+		return NewCustomNumericParser("", ".", ","), nil
+	default:
+		return NewCustomNumericParser("", ",", "."), nil
+	}
+}
@@ -0,0 +1,110 @@
+package multiparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferSeparators(t *testing.T) {
+	tests := []struct {
+		in  string
+		out separatorInference
+		err bool
+	}{
+		{"1234", separatorInference{}, false},
+		{"1,234.56", separatorInference{digit: ",", decimal: "."}, false},
+		{"1.234,56", separatorInference{digit: ".", decimal: ","}, false},
+		{"1,23,456.7", separatorInference{digit: ",", decimal: "."}, false},
+		{"1.234.567", separatorInference{digit: "."}, false},
+		{"1,23,456", separatorInference{digit: ","}, false},
+		{"12,34,567", separatorInference{digit: ","}, false},
+		{"0,123", separatorInference{decimal: ","}, false},
+		{"1234.567", separatorInference{decimal: "."}, false},
+		{"12,5", separatorInference{decimal: ","}, false},
+		{"123.456", separatorInference{digit: ".", ambiguous: true}, false},
+		{"1.234.5", separatorInference{}, true},
+		{"123.234,234,00", separatorInference{}, true},
+		{"123,23.234", separatorInference{}, true},
+		{"1,2345,678", separatorInference{}, true},
+		{"1,,234", separatorInference{}, true},
+	}
+
+	p := NewCustomNumericParser("", "", "")
+	for _, tt := range tests {
+		inf, err := inferSeparators(tt.in, p.digitRegex)
+		if tt.err {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.out, inf, tt.in)
+	}
+}
+
+func TestNumericParserSeparators(t *testing.T) {
+	tests := []struct {
+		in        string
+		out       float64
+		ambiguous bool
+	}{
+		{"1,23,456", 123456, false},
+		{"$12,34,567.89", 1234567.89, false},
+		{"1.234", 1234, true},
+		{"1.234,5", 1234.5, false},
+	}
+
+	p := NewCustomNumericParser("", "", "")
+	for _, tt := range tests {
+		n, err := p.ParseNumeric(tt.in)
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.out, n.Float(), tt.in)
+		assert.Equal(t, tt.ambiguous, n.IsAmbiguous(), tt.in)
+	}
+
+	_, err := p.ParseNumeric("1.234.5")
+	assert.Error(t, err)
+
+	usd := NewUSDNumericParser()
+	n, err := usd.ParseNumeric("$1,23,456.50")
+	assert.NoError(t, err)
+	assert.Equal(t, 123456.5, n.Float())
+	_, err = usd.ParseNumeric("1,23.456")
+	assert.Error(t, err)
+}
+
+func TestNumericParserRejectAmbiguous(t *testing.T) {
+	p := NewCustomNumericParser("", "", "")
+	p.RejectAmbiguous = true
+	_, err := p.ParseNumeric("123.456")
+	assert.EqualError(t, err, ParseAmbiguousSeparatorError)
+
+	n, err := p.ParseNumeric("123.4567")
+	assert.NoError(t, err)
+	assert.Equal(t, 123.4567, n.Float())
+}
+
+func TestLearnNumericParser(t *testing.T) {
+	p, err := LearnNumericParser([]string{"1.234", "12,5", "€3.000,25", "abc"})
+	assert.NoError(t, err)
+	assert.Equal(t, ".", p.DigitSeparator)
+	assert.Equal(t, ",", p.DecimalSeparator)
+	n, err := p.ParseNumeric("1.234")
+	assert.NoError(t, err)
+	assert.Equal(t, 1234.0, n.Float())
+	assert.False(t, n.IsAmbiguous())
+
+	p, err = LearnNumericParser([]string{"1.234", "0.5", "1,234,567"})
+	assert.NoError(t, err)
+	assert.Equal(t, ",", p.DigitSeparator)
+	n, err = p.ParseNumeric("1.234")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.234, n.Float())
+
+	p, err = LearnNumericParser([]string{"123.456"})
+	assert.NoError(t, err)
+	assert.Equal(t, ",", p.DigitSeparator)
+
+	_, err = LearnNumericParser([]string{"1,5", "1.5"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,248 @@
+package multiparse
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Value is a loosely typed value that implements the sql.Scanner and
+// driver.Valuer interfaces.  Text columns are parsed with the package's
+// general purpose Parser, and text that does not parse is kept as a
+// string, while already typed driver values are passed through.
+type Value struct {
+	*Parsed
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *Value) Scan(src interface{}) error {
+	if src == nil {
+		return errors.New(ScanNullError)
+	}
+	switch t := src.(type) {
+	case string:
+		return v.scanString(t)
+	case []byte:
+		return v.scanString(string(t))
+	case int64:
+		text := strconv.FormatInt(t, 10)
+		v.Parsed = &Parsed{
+			Numeric: &Numeric{
+				isInt:     true,
+				isFloat:   true,
+				f:         float64(t),
+				text:      text,
+				sanitized: text,
+			},
+			text:      text,
+			isNumeric: true,
+		}
+	case float64:
+		v.Parsed = &Parsed{
+			Numeric:   &Numeric{isFloat: true, f: t},
+			isNumeric: true,
+		}
+	case bool:
+		v.Parsed = NewParsed()
+		v.isBool = true
+		v.b = t
+	case time.Time:
+		v.Parsed = NewParsed()
+		v.isTime = true
+		v.time = t
+	default:
+		return errors.New(ScanTypeError)
+	}
+	return nil
+}
+
+func (v *Value) scanString(s string) error {
+	parsed, err := context.p.ParseType(s)
+	if err != nil {
+		parsed = NewParsed()
+		parsed.text = s
+	}
+	v.Parsed = parsed
+	return nil
+}
+
+// Value implements the driver.Valuer interface.  Integers, floats,
+// times and booleans are returned as int64, float64, time.Time and bool
+// values, in that order of preference.  Codes such as "02134" are
+// returned as strings, so that leading zeros are kept, and so are
+// strings of other kinds, such as email addresses.
+func (v Value) Value() (driver.Value, error) {
+	switch {
+	case v.Parsed == nil:
+		return nil, nil
+	case v.IsNumeric() && v.IsCode():
+		return v.Text(), nil
+	case v.IsInt():
+		// The sanitized text is exact where the float64 value may not be.
+		if i, err := strconv.ParseInt(v.Sanitized(), 10, 64); err == nil {
+			return i, nil
+		}
+		return int64(v.Int()), nil
+	case v.IsNumeric():
+		return v.Float(), nil
+	case v.IsTime():
+		return v.Time(), nil
+	case v.IsBool():
+		return v.Bool(), nil
+	}
+	return v.text, nil
+}
+
+// NullValue is a Value that may be NULL.
+type NullValue struct {
+	Parsed *Parsed
+	Valid  bool // Valid is true if Parsed is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *NullValue) Scan(src interface{}) error {
+	if src == nil {
+		v.Parsed, v.Valid = nil, false
+		return nil
+	}
+	var x Value
+	if err := x.Scan(src); err != nil {
+		return err
+	}
+	v.Parsed, v.Valid = x.Parsed, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (v NullValue) Value() (driver.Value, error) {
+	if !v.Valid {
+		return nil, nil
+	}
+	return Value{v.Parsed}.Value()
+}
+
+// Scan implements the sql.Scanner interface.  Text is parsed as a
+// numeric value that may have a currency symbol.
+func (m *Money) Scan(src interface{}) error {
+	switch t := src.(type) {
+	case nil:
+		return errors.New(ScanNullError)
+	case string:
+		return m.scanString(t)
+	case []byte:
+		return m.scanString(string(t))
+	case int64:
+		*m = Money{Amount: float64(t)}
+	case float64:
+		*m = Money{Amount: t}
+	default:
+		return errors.New(ScanTypeError)
+	}
+	return nil
+}
+
+func (m *Money) scanString(s string) error {
+	x, err := parseMoney(context.p, s)
+	if err != nil {
+		return err
+	}
+	*m = x
+	return nil
+}
+
+// Value implements the driver.Valuer interface by returning the amount.
+func (m Money) Value() (driver.Value, error) {
+	return m.Amount, nil
+}
+
+// NullMoney is a Money value that may be NULL.
+type NullMoney struct {
+	Money Money
+	Valid bool // Valid is true if Money is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (m *NullMoney) Scan(src interface{}) error {
+	if src == nil {
+		m.Money, m.Valid = Money{}, false
+		return nil
+	}
+	if err := m.Money.Scan(src); err != nil {
+		return err
+	}
+	m.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (m NullMoney) Value() (driver.Value, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	return m.Money.Value()
+}
+
+// FlexTime is a time.Time that can be scanned from text in any of the
+// layouts the package's general purpose Parser recognizes.
+type FlexTime struct {
+	time.Time
+}
+
+// Scan implements the sql.Scanner interface.
+func (t *FlexTime) Scan(src interface{}) error {
+	switch x := src.(type) {
+	case nil:
+		return errors.New(ScanNullError)
+	case string:
+		return t.scanString(x)
+	case []byte:
+		return t.scanString(string(x))
+	case time.Time:
+		t.Time = x
+	default:
+		return errors.New(ScanTypeError)
+	}
+	return nil
+}
+
+func (t *FlexTime) scanString(s string) error {
+	x, err := context.p.ParseTime(s)
+	if err != nil {
+		return err
+	}
+	t.Time = x
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (t FlexTime) Value() (driver.Value, error) {
+	return t.Time, nil
+}
+
+// NullFlexTime is a FlexTime that may be NULL.
+type NullFlexTime struct {
+	FlexTime FlexTime
+	Valid    bool // Valid is true if FlexTime is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (t *NullFlexTime) Scan(src interface{}) error {
+	if src == nil {
+		t.FlexTime, t.Valid = FlexTime{}, false
+		return nil
+	}
+	if err := t.FlexTime.Scan(src); err != nil {
+		return err
+	}
+	t.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (t NullFlexTime) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.FlexTime.Value()
+}
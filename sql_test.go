@@ -0,0 +1,143 @@
+package multiparse
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueScan(t *testing.T) {
+	day := time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in  interface{}
+		out driver.Value
+	}{
+		{"123", int64(123)},
+		{"02134", "02134"},
+		{[]byte("$1,234.5"), 1234.5},
+		{"2015-01-02", day},
+		{"yes", true},
+		{int64(7), int64(7)},
+		{int64(1<<62 + 1), int64(1<<62 + 1)},
+		{"-4611686018427387905", int64(-1<<62 - 1)},
+		{"hello", "hello"},
+		{"", ""},
+		{2.5, 2.5},
+		{false, false},
+		{day, day},
+	}
+
+	for _, tt := range tests {
+		var v Value
+		assert.NoError(t, v.Scan(tt.in), tt.in)
+		out, err := v.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tt.out, out, tt.in)
+	}
+
+	fails := []interface{}{nil, []int{1}, int32(1)}
+	for _, tt := range fails {
+		var v Value
+		assert.Error(t, v.Scan(tt), tt)
+	}
+}
+
+func TestValueScanText(t *testing.T) {
+	var v Value
+	assert.NoError(t, v.Scan("hello"))
+	assert.Equal(t, KindString, v.Kind())
+	assert.False(t, v.IsNumeric())
+}
+
+func TestValueString(t *testing.T) {
+	p := NewParser()
+	p.Register(NewEmailParser())
+	parsed, err := p.ParseType("user@example.com")
+	assert.NoError(t, err)
+
+	out, err := Value{parsed}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "user@example.com", out)
+}
+
+func TestNullValueScan(t *testing.T) {
+	var v NullValue
+	assert.NoError(t, v.Scan(nil))
+	assert.False(t, v.Valid)
+	out, err := v.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, out)
+
+	assert.NoError(t, v.Scan("12.5"))
+	assert.True(t, v.Valid)
+	assert.True(t, v.Parsed.IsFloat())
+	out, err = v.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, 12.5, out)
+}
+
+func TestMoneyScan(t *testing.T) {
+	tests := []struct {
+		in  interface{}
+		out Money
+	}{
+		{"$1,234.50", Money{Amount: 1234.5, Currency: "$"}},
+		{[]byte("€12"), Money{Amount: 12, Currency: "€"}},
+		{"12.5", Money{Amount: 12.5}},
+		{int64(3), Money{Amount: 3}},
+		{4.25, Money{Amount: 4.25}},
+	}
+
+	for _, tt := range tests {
+		var m Money
+		assert.NoError(t, m.Scan(tt.in), tt.in)
+		assert.Equal(t, tt.out, m)
+		out, err := m.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tt.out.Amount, out)
+	}
+
+	fails := []interface{}{nil, "abc", true, time.Now()}
+	for _, tt := range fails {
+		var m Money
+		assert.Error(t, m.Scan(tt), tt)
+	}
+
+	var nm NullMoney
+	assert.NoError(t, nm.Scan(nil))
+	assert.False(t, nm.Valid)
+	assert.NoError(t, nm.Scan("$5"))
+	assert.True(t, nm.Valid)
+	out, err := nm.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, out)
+}
+
+func TestFlexTimeScan(t *testing.T) {
+	day := time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC)
+	for _, in := range []interface{}{"2015-01-02", []byte("01/02/2015"), day} {
+		var ft FlexTime
+		assert.NoError(t, ft.Scan(in), in)
+		assert.Equal(t, day, ft.Time)
+		out, err := ft.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, day, out)
+	}
+
+	var ft FlexTime
+	assert.Error(t, ft.Scan(nil))
+	assert.Error(t, ft.Scan("abc"))
+	assert.Error(t, ft.Scan(int64(1)))
+
+	var nt NullFlexTime
+	assert.NoError(t, nt.Scan(nil))
+	assert.False(t, nt.Valid)
+	out, err := nt.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, out)
+	assert.NoError(t, nt.Scan("2015-01-02"))
+	assert.True(t, nt.Valid)
+	assert.Equal(t, day, nt.FlexTime.Time)
+}
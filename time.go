@@ -3,6 +3,7 @@ package multiparse
 import (
 	"errors"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -52,6 +53,31 @@ var commonDateLayouts = []string{
 	"01/02/2006",
 	"Jan 02 2006",
 	"Jan. 2 2006",
+	"2006/1/2",
+	"2006-1-2",
+}
+
+// Time is a datetime together with the layout and precision of the
+// string it was parsed from.
+type Time struct {
+	time.Time
+	layout    string
+	precision Precision
+	fiscal    bool
+	locale    string
+}
+
+// Layout of the string the time was parsed from.  It is "" for strings
+// that no Go layout describes, such as the ISO 8601 week date
+// "2021-W05-3", the quarter "Q3 2021" and dates in other languages.
+func (t Time) Layout() string {
+	return t.layout
+}
+
+// Precision is the smallest unit the string the time was parsed from
+// specifies, such as the month of "2021-02".
+func (t Time) Precision() Precision {
+	return t.precision
 }
 
 // TimeParser instances are responsible for parsing a string to determine
@@ -59,14 +85,37 @@ var commonDateLayouts = []string{
 // a number of datetime and date layouts.  The parser iterates over
 // these layouts and attempts to parse a string against them.
 type TimeParser struct {
+	// ISO8601 makes the parser also accept the ISO 8601 formats that Go
+	// layouts cannot express, such as the week date "2021-W05-3", the
+	// ordinal date "2021-035", the basic format "20210204T153000Z",
+	// comma fractions and dates of reduced precision such as "2021-02"
+	// and "2021".
+	ISO8601 bool
+	// PartialDates makes the parser also accept strings that name a
+	// period without giving a complete date, such as "Mar 2021",
+	// "Q3 2021", "H1 2020" and "FY22".  Such times are the start of the
+	// period, and Time.PartialDate returns the whole period.
+	PartialDates bool
+	// FiscalYearStart is the month fiscal years start in.  Zero means
+	// January, so that fiscal years are calendar years.
+	FiscalYearStart time.Month
+	// Locales are the languages of the TimeLocales table whose month and
+	// weekday names the parser accepts, as in "12 février 2021", tried
+	// in order.  See NewLocalizedTimeParser.
+	Locales []string
+	// Unexported fields.
 	timeLayouts []string
 	dateLayouts []string
 }
 
 // NewGeneralTimeParser returns a ready to use datetime parser that
-// attempts to detect datetimes using a number of standard layouts.
+// attempts to detect datetimes using a number of standard layouts, the
+// ISO 8601 formats and partial dates.
 func NewTimeParser() *TimeParser {
-	return NewCustomTimeParser(commonTimeLayouts, commonDateLayouts)
+	p := NewCustomTimeParser(commonTimeLayouts, commonDateLayouts)
+	p.ISO8601 = true
+	p.PartialDates = true
+	return p
 }
 
 // NewTimeParser produces a custom parser that will attempt to parse
@@ -78,40 +127,107 @@ func NewCustomTimeParser(timeLayouts []string, dateLayouts []string) *TimeParser
 	}
 }
 
-// Parse a string to determine if it represents a datetime.
+// Parse a string to determine if it represents a datetime.  The
+// returned value is a time.Time instance.
 func (p TimeParser) Parse(s string) (interface{}, error) {
-	return p.parse(s)
+	return p.ParseTime(s)
 }
 
-// ParseTime is the same as Parse but returns a *Time instance.
+// ParseTime is the same as Parse but returns a time.Time instance.
 func (p TimeParser) ParseTime(s string) (time.Time, error) {
+	t, err := p.parse(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Time, nil
+}
+
+// ParseTimeLayout is the same as Parse but returns a *Time instance that
+// records the layout and precision of the string.
+func (p TimeParser) ParseTimeLayout(s string) (*Time, error) {
 	return p.parse(s)
 }
 
+// A layoutParser is a time parser that records the layout of the
+// strings it parses, as the TimeParser does.
+type layoutParser interface {
+	ParseTimeLayout(s string) (*Time, error)
+}
+
 // The main datetime parsing logic.
-func (p TimeParser) parse(s string) (time.Time, error) {
+func (p TimeParser) parse(s string) (*Time, error) {
 	// Determine whether s has a valid layout that includes time.
-	for _, layout := range p.timeLayouts {
-		if pt, err := time.Parse(layout, s); err == nil {
-			return pt, nil
+	if t := parseLayouts(p.timeLayouts, s); t != nil {
+		return t, nil
+	}
+
+	// ISO 8601 strings are tried before the date prefix below, which
+	// would discard the time of "2021-02-04T15:30:00,123+01".
+	if p.ISO8601 {
+		if t, ok := parseISO8601(s); ok {
+			return t, nil
 		}
 	}
+	if p.PartialDates {
+		if t := p.parsePeriod(s); t != nil {
+			return t, nil
+		}
+	}
+	if t := p.parseLocalized(s); t != nil {
+		return t, nil
+	}
 
 	// Detect if the input has a date-like substring and try to parse that.
 	re := regexp.MustCompile("^\\d{1,4}([-/\\s]\\d{1,4}){2}")
 	d := re.FindString(s)
 
 	if d == "" {
-		var t time.Time
-		return t, errors.New(ParseTimeError)
+		return nil, errors.New(ParseTimeError)
 	}
 
-	for _, layout := range p.dateLayouts {
-		if pt, err := time.Parse(layout, d); err == nil {
-			return pt, nil
+	if t := parseLayouts(p.dateLayouts, d); t != nil {
+		return t, nil
+	}
+
+	return nil, errors.New(ParseTimeError)
+}
+
+// parseLayouts parses s with the first of the layouts that accepts it.
+// Several layouts may accept the same string, as "1/2/2006" and
+// "01/02/2006" both accept "03/04/2020", so a layout that formats the
+// parsed time back to s is preferred.
+func parseLayouts(layouts []string, s string) *Time {
+	var first *Time
+	for _, layout := range layouts {
+		pt, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		layout = fractionalLayout(layout, s, pt)
+		if pt.Format(layout) == s {
+			return &Time{Time: pt, layout: layout, precision: layoutPrecision(layout)}
+		}
+		if first == nil {
+			first = &Time{Time: pt, layout: layout, precision: layoutPrecision(layout)}
 		}
 	}
+	return first
+}
 
-	var t time.Time
-	return t, nil
+// fractionalLayout adds fractional seconds to a layout that lacks them.
+// When parsing, Go accepts fractional seconds immediately after the
+// seconds field even if the layout does not mention them, so a time
+// parsed from "2006-01-02 15:04:05.123" with the layout
+// "2006-01-02 15:04:05" would otherwise lose its fraction on formatting.
+func fractionalLayout(layout string, s string, t time.Time) string {
+	if t.Nanosecond() == 0 || !strings.Contains(layout, "05") {
+		return layout
+	}
+	for _, frac := range []string{"05.999999999", "05,999999999"} {
+		l := strings.Replace(layout, "05", frac, 1)
+		if pt, err := time.Parse(l, s); err == nil && pt.Equal(t) {
+			return l
+		}
+	}
+	return layout
 }
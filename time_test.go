@@ -59,3 +59,142 @@ func TestTimeParserParseType(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, actual)
 }
+
+func TestTimeParserFractionalSeconds(t *testing.T) {
+	p := NewTimeParser()
+	tm, err := p.ParseTimeLayout("2009-01-02 15:04:05.25")
+	assert.NoError(t, err)
+	assert.Equal(t, 250000000, tm.Nanosecond())
+	assert.Equal(t, "2009-01-02 15:04:05.25", tm.Format(tm.Layout()))
+
+	_, err = p.Parse("1234 5678 9012")
+	assert.Error(t, err)
+}
+
+func FuzzTimeParserParse(f *testing.F) {
+	seeds := []string{
+		"2009-01-02T15:04:05Z", "2009-01-02 15:04:05-0700", "2009/01/02",
+		"01/02/2009", "02/01/2009Tflaksdfj", "Jan 02 2006", "1/2/06 15:04",
+		"1234 5678 9012", "0000-01-01 0:00:00,1", "", "abc",
+		"2021-W05-3", "2021-035", "20210204T153000Z",
+		"2021-02-04T15:30:00,123+01", "2021-02", "2021", "9999-12-31T24:00",
+		"Mar 2021", "Q3 2021", "FY22", "H1 2020",
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	p := NewTimeParser()
+	f.Fuzz(func(t *testing.T, s string) {
+		tm, err := p.parse(s)
+		if err != nil {
+			if tm != nil {
+				t.Fatalf("%q: non-nil result with error %v", s, err)
+			}
+			return
+		}
+		if tm.Layout() == "" {
+			// Only ISO 8601 week dates, fractional hours and minutes,
+			// and quarters, halves and fiscal years have no layout.
+			if _, ok := parseISO8601(s); !ok && p.parsePeriod(s) == nil {
+				t.Fatalf("%q: parsed without a layout", s)
+			}
+			return
+		}
+
+		// Formatting the time with its layout parses to the same time.
+		formatted := tm.Format(tm.Layout())
+		tm2, err := p.parse(formatted)
+		if err != nil {
+			t.Fatalf("%q formatted as %q: %v", s, formatted, err)
+		}
+		if !tm2.Equal(tm.Time) {
+			t.Fatalf("%q formatted as %q parses to %v, not %v", s, formatted, tm2, tm)
+		}
+	})
+}
+
+func TestTimeParserISO8601(t *testing.T) {
+	cet := time.FixedZone("", 3600)
+	tests := []struct {
+		in        string
+		out       time.Time
+		precision Precision
+		layout    string
+	}{
+		{"2021", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), PrecisionYear, "2006"},
+		{"2021-02", time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), PrecisionMonth, "2006-01"},
+		{"2021-W05", time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), PrecisionWeek, ""},
+		{"2021-W05-3", time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC), PrecisionDay, ""},
+		{"2021W053", time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC), PrecisionDay, ""},
+		{"2020-W53-7", time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC), PrecisionDay, ""},
+		{"2021-035", time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC), PrecisionDay, "2006-002"},
+		{"2020366T12", time.Date(2020, 12, 31, 12, 0, 0, 0, time.UTC), PrecisionHour, "2006002T15"},
+		{"20210204T153000Z", time.Date(2021, 2, 4, 15, 30, 0, 0, time.UTC), PrecisionSecond, "20060102T150405Z0700"},
+		{"20210204T1530", time.Date(2021, 2, 4, 15, 30, 0, 0, time.UTC), PrecisionMinute, "20060102T1504"},
+		{
+			"2021-02-04T15:30:00,123+01",
+			time.Date(2021, 2, 4, 15, 30, 0, 123000000, cet),
+			PrecisionFraction,
+			"2006-01-02T15:04:05,000Z07",
+		},
+		{"2021-02-04T15", time.Date(2021, 2, 4, 15, 0, 0, 0, time.UTC), PrecisionHour, "2006-01-02T15"},
+		{"2021-02-04T15:30", time.Date(2021, 2, 4, 15, 30, 0, 0, time.UTC), PrecisionMinute, "2006-01-02T15:04"},
+		{"2021-02-04 15:30+01:00", time.Date(2021, 2, 4, 15, 30, 0, 0, cet), PrecisionMinute, "2006-01-02 15:04Z07:00"},
+		{"2021-02-04T15.5", time.Date(2021, 2, 4, 15, 30, 0, 0, time.UTC), PrecisionFraction, ""},
+		{"2021-02-04T15:30,5Z", time.Date(2021, 2, 4, 15, 30, 30, 0, time.UTC), PrecisionFraction, ""},
+	}
+
+	p := NewTimeParser()
+	for _, tt := range tests {
+		tm, err := p.ParseTimeLayout(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.True(t, tt.out.Equal(tm.Time), tt.in)
+		assert.Equal(t, tt.precision, tm.Precision(), tt.in)
+		assert.Equal(t, tt.layout, tm.Layout(), tt.in)
+		if tm.Layout() != "" {
+			assert.Equal(t, tt.in, tm.Format(tm.Layout()), tt.in)
+		}
+	}
+
+	fails := []string{
+		"2021-13", "2021-02-30", "2021-W54", "2021-W53", "2021-W05-8",
+		"2021-000", "2021-366", "2021-02T15:30", "202102", "20210204T15:30",
+		"1234", "0000", "9999", "1234100", "12340204", "99991231",
+		"20210204", "20001231", "2020366", "1999123",
+	}
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.Error(t, err, tt)
+	}
+
+	// The TimeParser reads these as the dates they begin with.
+	invalid := []string{
+		"2021-02-04T25:00", "2021-02-04T24:30", "2021-02-04T15:60",
+		"2021-02-04T15:30+24", "2021-02-04T",
+	}
+	for _, tt := range invalid {
+		_, ok := parseISO8601(tt)
+		assert.False(t, ok, tt)
+	}
+
+	// Midnight at the end of a day is midnight at the start of the next.
+	tm, err := p.ParseTime("2021-02-04T24:00")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 2, 5, 0, 0, 0, 0, time.UTC), tm)
+
+	_, err = NewCustomTimeParser(nil, nil).Parse("2021-W05-3")
+	assert.Error(t, err)
+
+	parsed, err := NewParser().ParseType("2021-02")
+	assert.NoError(t, err)
+	assert.Equal(t, KindTime, parsed.Kind())
+	assert.Equal(t, PrecisionMonth, parsed.TimePrecision())
+
+	parsed, err = NewParser().ParseType("2009-01-02 15:04:05")
+	assert.NoError(t, err)
+	assert.Equal(t, PrecisionSecond, parsed.TimePrecision())
+}
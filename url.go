@@ -0,0 +1,117 @@
+package multiparse
+
+import (
+	"errors"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+// DefaultURLSchemes are the schemes of the URLs a URLParser accepts by
+// default.
+var DefaultURLSchemes = []string{
+	"http", "https", "ftp", "ftps", "sftp", "ssh", "ws", "wss",
+	"mailto", "file", "s3", "gs",
+}
+
+// hostSchemes are the schemes whose URLs must have a host.
+var hostSchemes = map[string]bool{
+	"http": true, "https": true, "ftp": true, "ftps": true, "sftp": true,
+	"ssh": true, "ws": true, "wss": true, "s3": true, "gs": true,
+}
+
+// defaultPorts are the ports that are removed from normalized URLs.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ftp":   "21",
+	"ws":    "80",
+	"wss":   "443",
+}
+
+// A URLParser determines whether a string is an absolute URL or URI
+// with one of the parser's schemes, such as "https://example.com/a",
+// "s3://bucket/key", "file:///etc/hosts" or "mailto:jane@example.com".
+type URLParser struct {
+	schemes map[string]bool
+}
+
+// NewURLParser returns a URLParser that accepts the DefaultURLSchemes.
+func NewURLParser() *URLParser {
+	return NewCustomURLParser(DefaultURLSchemes)
+}
+
+// NewCustomURLParser returns a URLParser that accepts URLs with the
+// given schemes.
+func NewCustomURLParser(schemes []string) *URLParser {
+	m := make(map[string]bool)
+	for _, scheme := range schemes {
+		m[strings.ToLower(scheme)] = true
+	}
+	return &URLParser{schemes: m}
+}
+
+// Parse a string to determine if it is a URL.  The returned value is a
+// *url.URL instance.
+func (p URLParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParseURL is the same as Parse but returns a *url.URL instance.
+func (p URLParser) ParseURL(s string) (*url.URL, error) {
+	return p.parse(s)
+}
+
+// parse normalizes the URL by writing its scheme and host in lower case
+// and removing the scheme's default port, so that
+// "HTTP://Example.COM:80/a" becomes "http://example.com/a".  URLs with
+// network schemes such as "https" and "s3" must have a host, "file" URLs
+// must have a path, and "mailto" URLs must have an address.  URIs with
+// other schemes, such as "urn:isbn:0451450523", may be opaque.
+func (p URLParser) parse(s string) (*url.URL, error) {
+	parseErr := errors.New(ParseURLError)
+
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, " \t\n") {
+		return nil, parseErr
+	}
+	u, err := url.Parse(s)
+	if err != nil || !u.IsAbs() || !p.schemes[strings.ToLower(u.Scheme)] {
+		return nil, parseErr
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+
+	switch u.Scheme {
+	case "mailto":
+		if _, err := mail.ParseAddress(u.Opaque); err != nil {
+			return nil, parseErr
+		}
+		return u, nil
+	case "file":
+		if u.Opaque != "" || u.Path == "" {
+			return nil, parseErr
+		}
+	default:
+		if hostSchemes[u.Scheme] && (u.Opaque != "" || u.Host == "") {
+			return nil, parseErr
+		}
+		if u.Opaque == "" && u.Host == "" && u.Path == "" {
+			return nil, parseErr
+		}
+	}
+
+	host, port := u.Hostname(), u.Port()
+	host = strings.ToLower(host)
+	if port == defaultPorts[u.Scheme] {
+		port = ""
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	u.Host = host
+	if port != "" {
+		u.Host = net.JoinHostPort(strings.Trim(host, "[]"), port)
+	}
+	return u, nil
+}
@@ -0,0 +1,353 @@
+package multiparse
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// wordKind is the grammatical role of a token in a spelled-out number.
+type wordKind int
+
+const (
+	wordNone    wordKind = iota
+	wordUnit             // zero to nine
+	wordTeen             // ten to nineteen
+	wordTens             // twenty, thirty, ..., ninety
+	wordHundred          // hundred
+	wordDozen            // dozen
+	wordScale            // thousand, million, ...
+	wordArticle          // a, an
+	wordAnd              // and
+	wordNumeral          // digits, such as "1.2"
+)
+
+// A numberWord is the role and value of a word, and whether it is an
+// ordinal such as "third".
+type numberWord struct {
+	kind    wordKind
+	value   int64
+	ordinal bool
+}
+
+// NumberWords maps the English words for numbers, in lower case, to
+// their values.  Ordinal words such as "third" and "twentieth" are
+// included.
+var NumberWords = newNumberWordTable()
+
+func newNumberWordTable() map[string]numberWord {
+	m := make(map[string]numberWord)
+	add := func(kind wordKind, value int64, cardinal, ordinal string) {
+		m[cardinal] = numberWord{kind: kind, value: value}
+		m[ordinal] = numberWord{kind: kind, value: value, ordinal: true}
+	}
+
+	units := []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+	unitOrdinals := []string{"zeroth", "first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth", "ninth"}
+	for i := range units {
+		add(wordUnit, int64(i), units[i], unitOrdinals[i])
+	}
+	teens := []string{"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	for i, w := range teens {
+		ordinal := w + "th"
+		if w == "twelve" {
+			ordinal = "twelfth"
+		}
+		add(wordTeen, int64(10+i), w, ordinal)
+	}
+	tens := []string{"twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	for i, w := range tens {
+		add(wordTens, int64(20+10*i), w, strings.TrimSuffix(w, "y")+"ieth")
+	}
+
+	add(wordHundred, 100, "hundred", "hundredth")
+	add(wordDozen, 12, "dozen", "dozenth")
+	add(wordScale, 1e3, "thousand", "thousandth")
+	add(wordScale, 1e6, "million", "millionth")
+	add(wordScale, 1e9, "billion", "billionth")
+	add(wordScale, 1e12, "trillion", "trillionth")
+	m["a"] = numberWord{kind: wordArticle, value: 1}
+	m["an"] = numberWord{kind: wordArticle, value: 1}
+	m["and"] = numberWord{kind: wordAnd}
+	return m
+}
+
+var (
+	// Matches ordinal numerals such as "3rd" and "1,000th".
+	ordinalNumeralRegex = regexp.MustCompile("^(.*\\d)(?i:(st|nd|rd|th))$")
+	// Matches numerals with an attached abbreviation, such as "1.2k".
+	abbreviatedNumeralRegex = regexp.MustCompile("^(.*\\d)(\\pL+)$")
+)
+
+// A WordNumberParser determines whether a string spells out a number in
+// English, as in "twenty-three", "one hundred and five", "a dozen",
+// "twenty first" and "3rd", or mixes digits and magnitude words, as in
+// "1.2 million" and "4.5bn".  Numerals are parsed with a NumericParser.
+// The parser can be registered with a Parser to detect numbers the
+// Parser's NumericParser does not.
+type WordNumberParser struct {
+	numeric *NumericParser
+}
+
+// NewWordNumberParser returns a WordNumberParser that parses numerals
+// with the general NumericParser.
+func NewWordNumberParser() *WordNumberParser {
+	return NewCustomWordNumberParser(NewNumericParser())
+}
+
+// NewCustomWordNumberParser returns a WordNumberParser that parses
+// numerals with the given NumericParser.
+func NewCustomWordNumberParser(numeric *NumericParser) *WordNumberParser {
+	return &WordNumberParser{numeric: numeric}
+}
+
+// Parse a string to determine if it represents a number.  The returned
+// value is a *Numeric instance.
+func (p WordNumberParser) Parse(s string) (interface{}, error) {
+	return p.parse(s)
+}
+
+// ParseNumeric is the same as Parse but returns a *Numeric instance.
+func (p WordNumberParser) ParseNumeric(s string) (*Numeric, error) {
+	return p.parse(s)
+}
+
+// IsOrdinal reports if the original string is an ordinal number, such
+// as "3rd" or "twenty first".
+func (x Numeric) IsOrdinal() bool {
+	return x.ordinal
+}
+
+// A wordToken is a word or numeral of a spelled-out number.  Numerals
+// carry their parsed value.
+type wordToken struct {
+	numberWord
+	rat     *big.Rat
+	numeric *Numeric
+}
+
+// ordinalSuffix returns the suffix of the ordinal of an integer given
+// in decimal, e.g. "nd" for "22" and "th" for "12".
+func ordinalSuffix(digits string) string {
+	if n := len(digits); n >= 2 && digits[n-2] == '1' {
+		return "th"
+	}
+	switch digits[len(digits)-1] {
+	case '1':
+		return "st"
+	case '2':
+		return "nd"
+	case '3':
+		return "rd"
+	}
+	return "th"
+}
+
+// numeral parses a token that contains digits.  Ordinals such as "3rd"
+// must be integers with the correct suffix, and attached abbreviations
+// such as the "k" in "1.2k" become a second token.
+func (p WordNumberParser) numeral(s string) ([]wordToken, bool) {
+	parse := func(s string) (wordToken, bool) {
+		n, text, err := p.numeric.parseText(s)
+		if err != nil {
+			return wordToken{}, false
+		}
+		x, ok := new(big.Rat).SetString(text)
+		if !ok {
+			return wordToken{}, false
+		}
+		return wordToken{numberWord: numberWord{kind: wordNumeral}, rat: x, numeric: n}, true
+	}
+
+	if t, ok := parse(s); ok {
+		return []wordToken{t}, true
+	}
+	if m := ordinalNumeralRegex.FindStringSubmatch(s); m != nil {
+		t, ok := parse(m[1])
+		if !ok || !t.numeric.IsInt() || t.numeric.IsMoney() || t.rat.Sign() < 0 {
+			return nil, false
+		}
+		if strings.ToLower(m[2]) != ordinalSuffix(t.rat.Num().String()) {
+			return nil, false
+		}
+		t.ordinal = true
+		return []wordToken{t}, true
+	}
+	if m := abbreviatedNumeralRegex.FindStringSubmatch(s); m != nil {
+		v, prs := magnitudeSuffix(m[2])
+		t, ok := parse(m[1])
+		if !prs || !ok {
+			return nil, false
+		}
+		return []wordToken{t, {numberWord: numberWord{kind: wordScale, value: v}}}, true
+	}
+	return nil, false
+}
+
+// tokenize splits s into words and numerals.  Hyphens join words, as in
+// "twenty-three", and commas may follow words, as in "one thousand, two
+// hundred".  Abbreviations such as the "M" of "3 M" are only scale words
+// directly after a numeral, so that "a T" is not a trillion, but the
+// spelled words of IndianMagnitudeSuffixes, such as "lakh", are scale
+// words anywhere.
+func (p WordNumberParser) tokenize(s string) ([]wordToken, bool) {
+	var tokens []wordToken
+	for _, field := range strings.Fields(s) {
+		if strings.IndexFunc(field, unicode.IsDigit) >= 0 {
+			ts, ok := p.numeral(field)
+			if !ok {
+				return nil, false
+			}
+			tokens = append(tokens, ts...)
+			continue
+		}
+		if v, prs := magnitudeSuffix(field); prs && len(tokens) > 0 && tokens[len(tokens)-1].kind == wordNumeral {
+			tokens = append(tokens, wordToken{numberWord: numberWord{kind: wordScale, value: v}})
+			continue
+		}
+		for _, w := range strings.Split(strings.TrimSuffix(field, ","), "-") {
+			if v, prs := IndianMagnitudeSuffixes[strings.ToLower(w)]; prs {
+				tokens = append(tokens, wordToken{numberWord: numberWord{kind: wordScale, value: v}})
+				continue
+			}
+			nw, prs := NumberWords[strings.ToLower(w)]
+			if !prs {
+				return nil, false
+			}
+			tokens = append(tokens, wordToken{numberWord: nw})
+		}
+	}
+	return tokens, true
+}
+
+// follows lists the kinds of token each kind may follow.  A number may
+// begin with any kind that may follow wordNone.
+var follows = map[wordKind][]wordKind{
+	wordUnit:    {wordNone, wordTens, wordHundred, wordScale, wordAnd},
+	wordTeen:    {wordNone, wordHundred, wordScale, wordAnd},
+	wordTens:    {wordNone, wordHundred, wordScale, wordAnd},
+	wordNumeral: {wordNone, wordScale, wordAnd},
+	wordHundred: {wordUnit, wordTeen, wordTens, wordArticle, wordNumeral},
+	wordDozen:   {wordNone, wordUnit, wordTeen, wordTens, wordArticle, wordNumeral},
+	wordScale:   {wordUnit, wordTeen, wordTens, wordHundred, wordDozen, wordArticle, wordNumeral},
+	wordArticle: {wordNone},
+	wordAnd:     {wordHundred, wordDozen, wordScale},
+}
+
+func canFollow(kind, last wordKind) bool {
+	for _, k := range follows[kind] {
+		if k == last {
+			return true
+		}
+	}
+	return false
+}
+
+// parse sums the values of the tokens.  Units, teens and tens add to
+// the current group, "hundred" and "dozen" multiply it, and scales such
+// as "thousand" multiply the group and add it to the total.  Scales
+// must decrease, so "one thousand two million" is rejected.
+func (p WordNumberParser) parse(s string) (*Numeric, error) {
+	parseErr := errors.New(ParseNumericError)
+
+	text := s
+	s = strings.TrimSpace(s)
+	sign := ""
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasPrefix(lower, "minus "):
+		sign, s = "-", s[len("minus "):]
+	case strings.HasPrefix(lower, "negative "):
+		sign, s = "-", s[len("negative "):]
+	case len(s) > 1 && s[0] == '-' && unicode.IsLetter(rune(s[1])):
+		sign, s = "-", s[1:]
+	}
+
+	tokens, ok := p.tokenize(s)
+	if !ok || len(tokens) == 0 {
+		return nil, parseErr
+	}
+
+	// Plain numerals are parsed by the NumericParser alone.
+	if len(tokens) == 1 && tokens[0].kind == wordNumeral && !tokens[0].ordinal && sign == "" {
+		return tokens[0].numeric, nil
+	}
+
+	var (
+		total    = new(big.Rat)
+		group    = new(big.Rat)
+		scale    int64
+		last     = wordNone
+		currency string
+		money    bool
+		decimals int
+	)
+	for i, t := range tokens {
+		if !canFollow(t.kind, last) || (t.ordinal && i != len(tokens)-1) {
+			return nil, parseErr
+		}
+		v := new(big.Rat).SetInt64(t.value)
+		switch t.kind {
+		case wordUnit, wordTeen, wordTens:
+			group.Add(group, v)
+		case wordNumeral:
+			if t.numeric.IsMoney() {
+				if i != 0 {
+					return nil, parseErr
+				}
+				money, currency = true, t.numeric.format.currency
+			}
+			if t.rat.Sign() < 0 && (i != 0 || sign != "") {
+				return nil, parseErr
+			}
+			group.Add(group, t.rat)
+			if t.numeric.format.decimals > decimals {
+				decimals = t.numeric.format.decimals
+			}
+		case wordHundred:
+			if group.Cmp(v) >= 0 {
+				return nil, parseErr
+			}
+			group.Mul(group, v)
+		case wordDozen:
+			if last == wordNone {
+				group.SetInt64(1)
+			}
+			group.Mul(group, v)
+		case wordScale:
+			if scale != 0 && t.value >= scale {
+				return nil, parseErr
+			}
+			scale = t.value
+			total.Add(total, group.Mul(group, v))
+			group = new(big.Rat)
+		case wordArticle:
+			group.SetInt64(1)
+		}
+		last = t.kind
+	}
+	if last == wordArticle || last == wordAnd {
+		return nil, parseErr
+	}
+	total.Add(total, group)
+
+	if sign == "-" {
+		total.Neg(total)
+	}
+	if tokens[0].kind == wordNumeral && tokens[0].rat.Sign() < 0 {
+		sign = "-"
+	}
+	f, _ := total.Float64()
+	return &Numeric{
+		isInt:     total.IsInt(),
+		isFloat:   true,
+		isMoney:   money,
+		ordinal:   tokens[len(tokens)-1].ordinal,
+		f:         f,
+		text:      text,
+		sanitized: decimalText(total, decimals),
+		format:    numberFormat{currency: currency, sign: sign},
+	}, nil
+}
@@ -0,0 +1,122 @@
+package multiparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordNumberParserParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		f       float64
+		isInt   bool
+		ordinal bool
+	}{
+		{"zero", 0, true, false},
+		{"seven", 7, true, false},
+		{"Twelve", 12, true, false},
+		{"twenty-three", 23, true, false},
+		{"twenty three", 23, true, false},
+		{"one hundred and five", 105, true, false},
+		{"one hundred five", 105, true, false},
+		{"twelve hundred", 1200, true, false},
+		{"a hundred", 100, true, false},
+		{"a dozen", 12, true, false},
+		{"two dozen", 24, true, false},
+		{"dozen", 12, true, false},
+		{"one thousand, two hundred and thirty-four", 1234, true, false},
+		{"one thousand and one", 1001, true, false},
+		{"two million three hundred thousand", 2300000, true, false},
+		{"a million", 1e6, true, false},
+		{"minus five", -5, true, false},
+		{"negative twenty", -20, true, false},
+		{"-five", -5, true, false},
+		{"3rd", 3, true, true},
+		{"21st", 21, true, true},
+		{"12th", 12, true, true},
+		{"112th", 112, true, true},
+		{"1,000th", 1000, true, true},
+		{"first", 1, true, true},
+		{"twenty first", 21, true, true},
+		{"twenty-first", 21, true, true},
+		{"twelfth", 12, true, true},
+		{"fortieth", 40, true, true},
+		{"one hundredth", 100, true, true},
+		{"one hundred and first", 101, true, true},
+		{"1.2 million", 1200000, true, false},
+		{"1.5 thousand", 1500, true, false},
+		{"2.25 thousand", 2250, true, false},
+		{"1.2k", 1200, true, false},
+		{"3M", 3e6, true, false},
+		{"4.5bn", 4.5e9, true, false},
+		{"4.5 bn", 4.5e9, true, false},
+		{"1 million 200 thousand", 1200000, true, false},
+		{"0.5 million", 500000, true, false},
+		{"1.0001 k", 1000.1, false, false},
+		{"10 lakh", 1e6, true, false},
+		{"ten lakh", 1e6, true, false},
+		{"two crore", 2e7, true, false},
+		{"2 Cr", 2e7, true, false},
+		{"-1.5 million", -1500000, true, false},
+		{"123", 123, true, false},
+	}
+
+	p := NewWordNumberParser()
+	for _, tt := range tests {
+		n, err := p.ParseNumeric(tt.in)
+		assert.NoError(t, err, tt.in)
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tt.f, n.Float(), tt.in)
+		assert.Equal(t, tt.isInt, n.IsInt(), tt.in)
+		assert.Equal(t, tt.ordinal, n.IsOrdinal(), tt.in)
+		assert.False(t, n.IsMoney(), tt.in)
+	}
+
+	fails := []string{
+		"", "a", "and", "one and", "hundred", "thousand", "five five",
+		"twenty thirty", "twenty eleven", "one thousand two million",
+		"three hundred hundred", "first second", "first hundred", "3th",
+		"11st", "2.5th", "1.2x", "1.2m", "twenty-", "one two three",
+		"minus -5", "5 5", "minus", "apple", "a T", "M", "B", "Cr",
+		"twenty M", "1.2k M", "ten Cr",
+	}
+	for _, tt := range fails {
+		_, err := p.Parse(tt)
+		assert.EqualError(t, err, ParseNumericError, tt)
+	}
+}
+
+func TestWordNumberParserMoney(t *testing.T) {
+	n, err := NewWordNumberParser().ParseNumeric("$1.2 million")
+	assert.NoError(t, err)
+	assert.True(t, n.IsMoney())
+	assert.Equal(t, 1200000.0, n.Float())
+	assert.Equal(t, "$", n.format.currency)
+
+	_, err = NewWordNumberParser().Parse("one $5")
+	assert.Error(t, err)
+}
+
+func TestParserRegisterWordNumberParser(t *testing.T) {
+	p := NewParser()
+	p.Register(NewWordNumberParser())
+
+	parsed, err := p.ParseType("twenty first")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsNumeric())
+	assert.True(t, parsed.IsOrdinal())
+	assert.Equal(t, 21, parsed.Int())
+	assert.Equal(t, KindInt, parsed.Kind())
+
+	// The NumericParser's reading is kept.
+	parsed, err = p.ParseType("1,234.5")
+	assert.NoError(t, err)
+	assert.Equal(t, ",", parsed.format.digitSep)
+	assert.Equal(t, 1234.5, parsed.Float())
+
+	_, err = p.ParseType("twenty apples")
+	assert.EqualError(t, err, ParseError)
+}